@@ -1,7 +1,9 @@
 package albums
 
 import (
+	"context"
 	"encoding/json"
+	"iter"
 	"strconv"
 	"strings"
 
@@ -10,117 +12,177 @@ import (
 
 type (
 	Albums struct {
-		Send   func(method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)
-		Market string // An ISO 3166-1 alpha-2 country code, https://en.wikipedia.org/wiki/ISO_3166-1_alpha-2
-	}
-
-	getAlbum lib.Album
-
-	getSeveralAlbums lib.Albums
-
-	getAlbumTracks struct {
-		lib.ItemsHeaders
-		Items []lib.TrackSimpleObject `json:"items"`
-	}
-
-	getUsersSavedAlbums struct {
-		lib.ItemsHeaders
-		Items []struct {
-			AddedAt string `json:"added_at"`
-			lib.Album
-		} `json:"items"`
-	}
-
-	getNewReleases struct {
-		Albums struct {
-			lib.ItemsHeaders
-			Items []lib.AlbumSimple `json:"items"`
-		} `json:"albums"`
+		Send        func(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)
+		Market      string // An ISO 3166-1 alpha-2 country code, https://en.wikipedia.org/wiki/ISO_3166-1_alpha-2
+		Concurrency int    // Maximum batches sent at once when the IDs exceed the Spotify batch limit, values below 2 send batches sequentially.
 	}
 )
 
-func New(send func(method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)) Albums {
+func New(send func(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)) Albums {
 	return Albums{Send: send, Market: ""}
 }
 
-func (s *Albums) GetAlbum(id string) (getAlbum, error) {
-	res, err := s.Send(lib.GET, "albums/"+id, [][2]string{{"market", s.Market}}, []byte{})
+func (s *Albums) GetAlbum(id string) (lib.AlbumObject, error) {
+	return s.GetAlbumCtx(context.Background(), id)
+}
+
+func (s *Albums) GetAlbumCtx(ctx context.Context, id string) (lib.AlbumObject, error) {
+	res, err := s.Send(ctx, lib.GET, "albums/"+id, [][2]string{{"market", s.Market}}, []byte{})
 	if err != nil {
-		return getAlbum{}, err
+		return lib.AlbumObject{}, err
 	}
-	data := getAlbum{}
+	data := lib.AlbumObject{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
 
-func (s *Albums) GetSeveralAlbums(ids []string) (getSeveralAlbums, error) {
-	res, err := s.Send(lib.GET, "albums", [][2]string{{"market", s.Market}, {"ids", strings.Join(ids, ",")}}, []byte{})
+func (s *Albums) GetSeveralAlbums(ids []string) (lib.Albums, error) {
+	return s.GetSeveralAlbumsCtx(context.Background(), ids)
+}
+
+func (s *Albums) GetSeveralAlbumsCtx(ctx context.Context, ids []string) (lib.Albums, error) {
+	items, err := lib.Batch(ids, 20, s.Concurrency, func(ids []string) ([]lib.AlbumObject, error) {
+		res, err := s.Send(ctx, lib.GET, "albums", [][2]string{{"market", s.Market}, {"ids", strings.Join(ids, ",")}}, []byte{})
+		if err != nil {
+			return nil, err
+		}
+		data := lib.Albums{}
+		err = json.Unmarshal(res, &data)
+		return data.Albums, err
+	})
 	if err != nil {
-		return getSeveralAlbums{}, err
+		return lib.Albums{}, err
 	}
-	data := getSeveralAlbums{}
-	err = json.Unmarshal(res, &data)
-	return data, err
+	return lib.Albums{Albums: items}, nil
+}
+
+func (s *Albums) GetAlbumTracks(id string, limit, offset int) (lib.Paging[lib.TrackSimpleObject], error) {
+	return s.GetAlbumTracksCtx(context.Background(), id, limit, offset)
 }
 
-func (s *Albums) GetAlbumTracks(id string, limit, offset int) (getAlbumTracks, error) {
-	res, err := s.Send(lib.GET, "albums/"+id+"/tracks", [][2]string{{"market", s.Market}, {"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
+func (s *Albums) GetAlbumTracksCtx(ctx context.Context, id string, limit, offset int) (lib.Paging[lib.TrackSimpleObject], error) {
+	res, err := s.Send(ctx, lib.GET, "albums/"+id+"/tracks", [][2]string{{"market", s.Market}, {"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
 	if err != nil {
-		return getAlbumTracks{}, err
+		return lib.Paging[lib.TrackSimpleObject]{}, err
 	}
-	data := getAlbumTracks{}
+	data := lib.Paging[lib.TrackSimpleObject]{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
 
+func (s *Albums) AllAlbumTracks(id string) iter.Seq2[lib.TrackSimpleObject, error] {
+	return s.AllAlbumTracksCtx(context.Background(), id)
+}
+
+func (s *Albums) AllAlbumTracksCtx(ctx context.Context, id string) iter.Seq2[lib.TrackSimpleObject, error] {
+	return lib.Paginate(func(offset int) ([]lib.TrackSimpleObject, lib.ItemsHeaders, error) {
+		data, err := s.GetAlbumTracksCtx(ctx, id, 50, offset)
+		return data.Items, data.ItemsHeaders, err
+	})
+}
+
 // Scopes: `ScopeUserLibraryRead`
-func (s *Albums) GetUsersSavedAlbums(limit, offset int) (getUsersSavedAlbums, error) {
-	res, err := s.Send(lib.GET, "me/albums", [][2]string{{"market", s.Market}, {"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
+func (s *Albums) GetUsersSavedAlbums(limit, offset int) (lib.Paging[lib.SavedAlbum], error) {
+	return s.GetUsersSavedAlbumsCtx(context.Background(), limit, offset)
+}
+
+// Scopes: `ScopeUserLibraryRead`
+func (s *Albums) GetUsersSavedAlbumsCtx(ctx context.Context, limit, offset int) (lib.Paging[lib.SavedAlbum], error) {
+	res, err := s.Send(ctx, lib.GET, "me/albums", [][2]string{{"market", s.Market}, {"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
 	if err != nil {
-		return getUsersSavedAlbums{}, err
+		return lib.Paging[lib.SavedAlbum]{}, err
 	}
-	data := getUsersSavedAlbums{}
+	data := lib.Paging[lib.SavedAlbum]{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
 
+// Scopes: `ScopeUserLibraryRead`
+func (s *Albums) AllUsersSavedAlbums() iter.Seq2[lib.SavedAlbum, error] {
+	return s.AllUsersSavedAlbumsCtx(context.Background())
+}
+
+// Scopes: `ScopeUserLibraryRead`
+func (s *Albums) AllUsersSavedAlbumsCtx(ctx context.Context) iter.Seq2[lib.SavedAlbum, error] {
+	return lib.Paginate(func(offset int) ([]lib.SavedAlbum, lib.ItemsHeaders, error) {
+		data, err := s.GetUsersSavedAlbumsCtx(ctx, 50, offset)
+		return data.Items, data.ItemsHeaders, err
+	})
+}
+
 // Scopes: `ScopeUserLibraryModify`
 func (s *Albums) SaveAlbumsForCurrentUser(ids []string) error {
-	body, err := json.Marshal(map[string]any{"ids": ids})
-	if err != nil {
+	return s.SaveAlbumsForCurrentUserCtx(context.Background(), ids)
+}
+
+// Scopes: `ScopeUserLibraryModify`
+func (s *Albums) SaveAlbumsForCurrentUserCtx(ctx context.Context, ids []string) error {
+	return lib.BatchDo(ids, 20, s.Concurrency, func(ids []string) error {
+		body, err := json.Marshal(map[string]any{"ids": ids})
+		if err != nil {
+			return err
+		}
+		_, err = s.Send(ctx, lib.PUT, "me/albums", [][2]string{}, body)
 		return err
-	}
-	_, err = s.Send(lib.PUT, "me/albums", [][2]string{}, body)
-	return err
+	})
 }
 
 // Scopes: `ScopeUserLibraryModify`
 func (s *Albums) RemoveUsersSavedAlbums(ids []string) error {
-	body, err := json.Marshal(map[string]any{"ids": ids})
-	if err != nil {
+	return s.RemoveUsersSavedAlbumsCtx(context.Background(), ids)
+}
+
+// Scopes: `ScopeUserLibraryModify`
+func (s *Albums) RemoveUsersSavedAlbumsCtx(ctx context.Context, ids []string) error {
+	return lib.BatchDo(ids, 20, s.Concurrency, func(ids []string) error {
+		body, err := json.Marshal(map[string]any{"ids": ids})
+		if err != nil {
+			return err
+		}
+		_, err = s.Send(ctx, lib.DELETE, "me/albums", [][2]string{}, body)
 		return err
-	}
-	_, err = s.Send(lib.DELETE, "me/albums", [][2]string{}, body)
-	return err
+	})
 }
 
 // Scopes: `ScopeUserLibraryRead`
 func (s *Albums) CheckUsersSavedAlbums(ids []string) ([]bool, error) {
-	res, err := s.Send(lib.GET, "me/albums/contains", [][2]string{{"ids", strings.Join(ids, ",")}}, []byte{})
-	if err != nil {
-		return []bool{}, err
-	}
-	data := []bool{}
-	err = json.Unmarshal(res, &data)
-	return data, err
+	return s.CheckUsersSavedAlbumsCtx(context.Background(), ids)
+}
+
+// Scopes: `ScopeUserLibraryRead`
+func (s *Albums) CheckUsersSavedAlbumsCtx(ctx context.Context, ids []string) ([]bool, error) {
+	return lib.Batch(ids, 20, s.Concurrency, func(ids []string) ([]bool, error) {
+		res, err := s.Send(ctx, lib.GET, "me/albums/contains", [][2]string{{"ids", strings.Join(ids, ",")}}, []byte{})
+		if err != nil {
+			return []bool{}, err
+		}
+		data := []bool{}
+		err = json.Unmarshal(res, &data)
+		return data, err
+	})
 }
 
-func (s *Albums) GetNewReleases(limit, offset int) (getNewReleases, error) {
-	res, err := s.Send(lib.GET, "browse/new-releases", [][2]string{{"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
+func (s *Albums) GetNewReleases(limit, offset int) (lib.NewReleases, error) {
+	return s.GetNewReleasesCtx(context.Background(), limit, offset)
+}
+
+func (s *Albums) GetNewReleasesCtx(ctx context.Context, limit, offset int) (lib.NewReleases, error) {
+	res, err := s.Send(ctx, lib.GET, "browse/new-releases", [][2]string{{"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
 	if err != nil {
-		return getNewReleases{}, err
+		return lib.NewReleases{}, err
 	}
-	data := getNewReleases{}
+	data := lib.NewReleases{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
+
+func (s *Albums) AllNewReleases() iter.Seq2[lib.AlbumSimpleObject, error] {
+	return s.AllNewReleasesCtx(context.Background())
+}
+
+func (s *Albums) AllNewReleasesCtx(ctx context.Context) iter.Seq2[lib.AlbumSimpleObject, error] {
+	return lib.Paginate(func(offset int) ([]lib.AlbumSimpleObject, lib.ItemsHeaders, error) {
+		data, err := s.GetNewReleasesCtx(ctx, 50, offset)
+		return data.Albums.Items, data.Albums.ItemsHeaders, err
+	})
+}
@@ -1,7 +1,9 @@
 package artists
 
 import (
+	"context"
 	"encoding/json"
+	"iter"
 	"strconv"
 	"strings"
 
@@ -10,66 +12,89 @@ import (
 
 type (
 	Artists struct {
-		Send   func(method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)
-		Market string // An ISO 3166-1 alpha-2 country code, https://en.wikipedia.org/wiki/ISO_3166-1_alpha-2
+		Send        func(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)
+		Market      string // An ISO 3166-1 alpha-2 country code, https://en.wikipedia.org/wiki/ISO_3166-1_alpha-2
+		Concurrency int    // Maximum batches sent at once when the IDs exceed the Spotify batch limit, values below 2 send batches sequentially.
 	}
-
-	getArtist lib.ArtistObject
-
-	getSeveralArtists lib.Artists
-
-	getArtistsAlbums struct {
-		lib.ItemsHeaders
-		Items []lib.AlbumSimpleObject `json:"items"`
-	}
-
-	getArtistsTopTracks lib.Tracks
 )
 
-func New(send func(method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)) Artists {
+func New(send func(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)) Artists {
 	return Artists{Send: send, Market: ""}
 }
 
-func (s *Artists) GetArtist(id string) (getArtist, error) {
-	res, err := s.Send(lib.GET, "artists/"+id, [][2]string{}, []byte{})
+func (s *Artists) GetArtist(id string) (lib.ArtistObject, error) {
+	return s.GetArtistCtx(context.Background(), id)
+}
+
+func (s *Artists) GetArtistCtx(ctx context.Context, id string) (lib.ArtistObject, error) {
+	res, err := s.Send(ctx, lib.GET, "artists/"+id, [][2]string{}, []byte{})
 	if err != nil {
-		return getArtist{}, err
+		return lib.ArtistObject{}, err
 	}
-	data := getArtist{}
+	data := lib.ArtistObject{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
 
-func (s *Artists) GetSeveralArtists(ids []string) (getSeveralArtists, error) {
-	res, err := s.Send(lib.GET, "artists", [][2]string{{"ids", strings.Join(ids, ",")}}, []byte{})
+func (s *Artists) GetSeveralArtists(ids []string) (lib.Artists, error) {
+	return s.GetSeveralArtistsCtx(context.Background(), ids)
+}
+
+func (s *Artists) GetSeveralArtistsCtx(ctx context.Context, ids []string) (lib.Artists, error) {
+	items, err := lib.Batch(ids, 50, s.Concurrency, func(ids []string) ([]lib.ArtistObject, error) {
+		res, err := s.Send(ctx, lib.GET, "artists", [][2]string{{"ids", strings.Join(ids, ",")}}, []byte{})
+		if err != nil {
+			return nil, err
+		}
+		data := lib.Artists{}
+		err = json.Unmarshal(res, &data)
+		return data.Artists, err
+	})
 	if err != nil {
-		return getSeveralArtists{}, err
+		return lib.Artists{}, err
 	}
-	data := getSeveralArtists{}
-	err = json.Unmarshal(res, &data)
-	return data, err
+	return lib.Artists{Artists: items}, nil
+}
+
+func (s *Artists) GetArtistsAlbums(id string, groups []lib.AlbumGroup, limit, offset int) (lib.Paging[lib.AlbumSimpleObject], error) {
+	return s.GetArtistsAlbumsCtx(context.Background(), id, groups, limit, offset)
 }
 
-func (s *Artists) GetArtistsAlbums(id string, groups []lib.AlbumGroup, limit, offset int) (getArtistsAlbums, error) {
+func (s *Artists) GetArtistsAlbumsCtx(ctx context.Context, id string, groups []lib.AlbumGroup, limit, offset int) (lib.Paging[lib.AlbumSimpleObject], error) {
 	grps := []string{}
 	for _, grp := range groups {
 		grps = append(grps, string(grp))
 	}
-	res, err := s.Send(lib.GET, "artists/"+id+"/albums", [][2]string{{"include_groups", strings.Join(grps, ",")}, {"market", s.Market}, {"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
+	res, err := s.Send(ctx, lib.GET, "artists/"+id+"/albums", [][2]string{{"include_groups", strings.Join(grps, ",")}, {"market", s.Market}, {"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
 	if err != nil {
-		return getArtistsAlbums{}, err
+		return lib.Paging[lib.AlbumSimpleObject]{}, err
 	}
-	data := getArtistsAlbums{}
+	data := lib.Paging[lib.AlbumSimpleObject]{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
 
-func (s *Artists) GetArtistsTopTracks(id string) (getArtistsTopTracks, error) {
-	res, err := s.Send(lib.GET, "artists/"+id+"/top-tracks", [][2]string{{"market", s.Market}}, []byte{})
+func (s *Artists) AllArtistsAlbums(id string, groups []lib.AlbumGroup) iter.Seq2[lib.AlbumSimpleObject, error] {
+	return s.AllArtistsAlbumsCtx(context.Background(), id, groups)
+}
+
+func (s *Artists) AllArtistsAlbumsCtx(ctx context.Context, id string, groups []lib.AlbumGroup) iter.Seq2[lib.AlbumSimpleObject, error] {
+	return lib.Paginate(func(offset int) ([]lib.AlbumSimpleObject, lib.ItemsHeaders, error) {
+		data, err := s.GetArtistsAlbumsCtx(ctx, id, groups, 50, offset)
+		return data.Items, data.ItemsHeaders, err
+	})
+}
+
+func (s *Artists) GetArtistsTopTracks(id string) (lib.Tracks, error) {
+	return s.GetArtistsTopTracksCtx(context.Background(), id)
+}
+
+func (s *Artists) GetArtistsTopTracksCtx(ctx context.Context, id string) (lib.Tracks, error) {
+	res, err := s.Send(ctx, lib.GET, "artists/"+id+"/top-tracks", [][2]string{{"market", s.Market}}, []byte{})
 	if err != nil {
-		return getArtistsTopTracks{}, err
+		return lib.Tracks{}, err
 	}
-	data := getArtistsTopTracks{}
+	data := lib.Tracks{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
@@ -1,7 +1,9 @@
 package audiobooks
 
 import (
+	"context"
 	"encoding/json"
+	"iter"
 	"strconv"
 	"strings"
 
@@ -10,89 +12,144 @@ import (
 
 type (
 	Audiobooks struct {
-		Send   func(method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)
-		Market string // An ISO 3166-1 alpha-2 country code, https://en.wikipedia.org/wiki/ISO_3166-1_alpha-2
-	}
-
-	getAnAudiobook lib.AudiobookObject
-
-	getSeveralAudiobooks lib.Audiobooks
-
-	getAudiobookChapters struct {
-		lib.ItemsHeaders
-		Items []lib.ChapterSimpleObject `json:"items"`
-	}
-
-	getUsersSavedAudiobooks struct {
-		lib.ItemsHeaders
-		Items []lib.AudiobookObject `json:"items"`
+		Send        func(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)
+		Market      string // An ISO 3166-1 alpha-2 country code, https://en.wikipedia.org/wiki/ISO_3166-1_alpha-2
+		Concurrency int    // Maximum batches sent at once when the IDs exceed the Spotify batch limit, values below 2 send batches sequentially.
 	}
 )
 
-func New(send func(method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)) Audiobooks {
+func New(send func(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)) Audiobooks {
 	return Audiobooks{Send: send, Market: ""}
 }
 
-func (s *Audiobooks) GetAnAudiobook(id string) (getAnAudiobook, error) {
-	res, err := s.Send(lib.GET, "audiobooks/"+id, [][2]string{{"market", s.Market}}, []byte{})
+func (s *Audiobooks) GetAnAudiobook(id string) (lib.AudiobookObject, error) {
+	return s.GetAnAudiobookCtx(context.Background(), id)
+}
+
+func (s *Audiobooks) GetAnAudiobookCtx(ctx context.Context, id string) (lib.AudiobookObject, error) {
+	res, err := s.Send(ctx, lib.GET, "audiobooks/"+id, [][2]string{{"market", s.Market}}, []byte{})
 	if err != nil {
-		return getAnAudiobook{}, err
+		return lib.AudiobookObject{}, err
 	}
-	data := getAnAudiobook{}
+	data := lib.AudiobookObject{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
 
-func (s *Audiobooks) GetSeveralAudiobooks(ids []string) (getSeveralAudiobooks, error) {
-	res, err := s.Send(lib.GET, "audiobooks", [][2]string{{"market", s.Market}, {"ids", strings.Join(ids, ",")}}, []byte{})
+func (s *Audiobooks) GetSeveralAudiobooks(ids []string) (lib.Audiobooks, error) {
+	return s.GetSeveralAudiobooksCtx(context.Background(), ids)
+}
+
+func (s *Audiobooks) GetSeveralAudiobooksCtx(ctx context.Context, ids []string) (lib.Audiobooks, error) {
+	items, err := lib.Batch(ids, 50, s.Concurrency, func(ids []string) ([]lib.AudiobookObject, error) {
+		res, err := s.Send(ctx, lib.GET, "audiobooks", [][2]string{{"market", s.Market}, {"ids", strings.Join(ids, ",")}}, []byte{})
+		if err != nil {
+			return nil, err
+		}
+		data := lib.Audiobooks{}
+		err = json.Unmarshal(res, &data)
+		return data.Audiobooks, err
+	})
 	if err != nil {
-		return getSeveralAudiobooks{}, err
+		return lib.Audiobooks{}, err
 	}
-	data := getSeveralAudiobooks{}
-	err = json.Unmarshal(res, &data)
-	return data, err
+	return lib.Audiobooks{Audiobooks: items}, nil
 }
 
-func (s *Audiobooks) GetAudiobookChapters(id string, limit, offset int) (getAudiobookChapters, error) {
-	res, err := s.Send(lib.GET, "audiobooks/"+id+"/chapters", [][2]string{{"market", s.Market}, {"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
+func (s *Audiobooks) GetAudiobookChapters(id string, limit, offset int) (lib.Paging[lib.ChapterSimpleObject], error) {
+	return s.GetAudiobookChaptersCtx(context.Background(), id, limit, offset)
+}
+
+func (s *Audiobooks) GetAudiobookChaptersCtx(ctx context.Context, id string, limit, offset int) (lib.Paging[lib.ChapterSimpleObject], error) {
+	res, err := s.Send(ctx, lib.GET, "audiobooks/"+id+"/chapters", [][2]string{{"market", s.Market}, {"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
 	if err != nil {
-		return getAudiobookChapters{}, err
+		return lib.Paging[lib.ChapterSimpleObject]{}, err
 	}
-	data := getAudiobookChapters{}
+	data := lib.Paging[lib.ChapterSimpleObject]{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
 
+func (s *Audiobooks) AllAudiobookChapters(id string) iter.Seq2[lib.ChapterSimpleObject, error] {
+	return s.AllAudiobookChaptersCtx(context.Background(), id)
+}
+
+func (s *Audiobooks) AllAudiobookChaptersCtx(ctx context.Context, id string) iter.Seq2[lib.ChapterSimpleObject, error] {
+	return lib.Paginate(func(offset int) ([]lib.ChapterSimpleObject, lib.ItemsHeaders, error) {
+		data, err := s.GetAudiobookChaptersCtx(ctx, id, 50, offset)
+		return data.Items, data.ItemsHeaders, err
+	})
+}
+
+// Scopes: `ScopeUserLibraryRead`
+func (s *Audiobooks) GetUsersSavedAudiobooks(limit, offset int) (lib.Paging[lib.AudiobookObject], error) {
+	return s.GetUsersSavedAudiobooksCtx(context.Background(), limit, offset)
+}
+
 // Scopes: `ScopeUserLibraryRead`
-func (s *Audiobooks) GetUsersSavedAudiobooks(limit, offset int) (getUsersSavedAudiobooks, error) {
-	res, err := s.Send(lib.GET, "me/audiobooks", [][2]string{{"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
+func (s *Audiobooks) GetUsersSavedAudiobooksCtx(ctx context.Context, limit, offset int) (lib.Paging[lib.AudiobookObject], error) {
+	res, err := s.Send(ctx, lib.GET, "me/audiobooks", [][2]string{{"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
 	if err != nil {
-		return getUsersSavedAudiobooks{}, err
+		return lib.Paging[lib.AudiobookObject]{}, err
 	}
-	data := getUsersSavedAudiobooks{}
+	data := lib.Paging[lib.AudiobookObject]{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
 
+// Scopes: `ScopeUserLibraryRead`
+func (s *Audiobooks) AllUsersSavedAudiobooks() iter.Seq2[lib.AudiobookObject, error] {
+	return s.AllUsersSavedAudiobooksCtx(context.Background())
+}
+
+// Scopes: `ScopeUserLibraryRead`
+func (s *Audiobooks) AllUsersSavedAudiobooksCtx(ctx context.Context) iter.Seq2[lib.AudiobookObject, error] {
+	return lib.Paginate(func(offset int) ([]lib.AudiobookObject, lib.ItemsHeaders, error) {
+		data, err := s.GetUsersSavedAudiobooksCtx(ctx, 50, offset)
+		return data.Items, data.ItemsHeaders, err
+	})
+}
+
 // Scopes: `ScopeUserLibraryModify`
 func (s *Audiobooks) SaveAudiobooksForCurrentUser(ids []string) error {
-	_, err := s.Send(lib.PUT, "me/audiobooks", [][2]string{{"ids", strings.Join(ids, ",")}}, []byte{})
-	return err
+	return s.SaveAudiobooksForCurrentUserCtx(context.Background(), ids)
+}
+
+// Scopes: `ScopeUserLibraryModify`
+func (s *Audiobooks) SaveAudiobooksForCurrentUserCtx(ctx context.Context, ids []string) error {
+	return lib.BatchDo(ids, 50, s.Concurrency, func(ids []string) error {
+		_, err := s.Send(ctx, lib.PUT, "me/audiobooks", [][2]string{{"ids", strings.Join(ids, ",")}}, []byte{})
+		return err
+	})
 }
 
 // Scopes: `ScopeUserLibraryModify`
 func (s *Audiobooks) RemoveUsersSavedAudiobooks(ids []string) error {
-	_, err := s.Send(lib.DELETE, "me/audiobooks", [][2]string{{"ids", strings.Join(ids, ",")}}, []byte{})
-	return err
+	return s.RemoveUsersSavedAudiobooksCtx(context.Background(), ids)
+}
+
+// Scopes: `ScopeUserLibraryModify`
+func (s *Audiobooks) RemoveUsersSavedAudiobooksCtx(ctx context.Context, ids []string) error {
+	return lib.BatchDo(ids, 50, s.Concurrency, func(ids []string) error {
+		_, err := s.Send(ctx, lib.DELETE, "me/audiobooks", [][2]string{{"ids", strings.Join(ids, ",")}}, []byte{})
+		return err
+	})
 }
 
 // Scopes: `ScopeUserLibraryRead`
 func (s *Audiobooks) CheckUsersSavedAudiobooks(ids []string) ([]bool, error) {
-	res, err := s.Send(lib.GET, "me/audiobooks/contains", [][2]string{{"ids", strings.Join(ids, ",")}}, []byte{})
-	if err != nil {
-		return []bool{}, err
-	}
-	data := []bool{}
-	err = json.Unmarshal(res, &data)
-	return data, err
+	return s.CheckUsersSavedAudiobooksCtx(context.Background(), ids)
+}
+
+// Scopes: `ScopeUserLibraryRead`
+func (s *Audiobooks) CheckUsersSavedAudiobooksCtx(ctx context.Context, ids []string) ([]bool, error) {
+	return lib.Batch(ids, 50, s.Concurrency, func(ids []string) ([]bool, error) {
+		res, err := s.Send(ctx, lib.GET, "me/audiobooks/contains", [][2]string{{"ids", strings.Join(ids, ",")}}, []byte{})
+		if err != nil {
+			return []bool{}, err
+		}
+		data := []bool{}
+		err = json.Unmarshal(res, &data)
+		return data, err
+	})
 }
@@ -0,0 +1,266 @@
+package gotify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/HandyGold75/gotify/lib"
+	"golang.org/x/oauth2"
+)
+
+// authCallback holds the query of a redirect after login, done receives the result of handling it when not nil.
+type authCallback struct {
+	values url.Values
+	done   chan<- error
+}
+
+const authPage = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>%s</title></head>
+<body style="font-family: sans-serif; text-align: center; margin-top: 10%%;">
+<h1>%s</h1>
+<p>%s</p>
+</body>
+</html>
+`
+
+// authorize runs the authorization code flow with PKCE for state, the login URL is passed to prompt and the redirect after login is expected on callbacks.
+//
+// Callbacks with an unexpected state are answered with `lib.Errors.InvalidState` and ignored, except for pasted callbacks which fail the flow as no other paste will follow.
+// The first other callback completes the flow, the flow is aborted when ctx is done, returning the cause of ctx.
+func (gp *GotifyPlayer) authorize(ctx context.Context, state string, prompt func(url string), callbacks <-chan authCallback) error {
+	if gp.AuthTimeout > 0 {
+		c, cancel := context.WithTimeout(ctx, gp.AuthTimeout)
+		defer cancel()
+		ctx = c
+	}
+	verifier := oauth2.GenerateVerifier()
+	prompt(gp.authCfg.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.S256ChallengeOption(verifier)))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		case cb := <-callbacks:
+			if cb.values.Get("state") != state {
+				if cb.done == nil {
+					return fmt.Errorf("%w: pasted URL is not the redirect of this login", lib.Errors.InvalidState)
+				}
+				reply(cb, lib.Errors.InvalidState)
+				continue
+			}
+			err := gp.exchange(ctx, cb.values, verifier)
+			reply(cb, err)
+			return err
+		}
+	}
+}
+
+func (gp *GotifyPlayer) exchange(ctx context.Context, values url.Values, verifier string) error {
+	if e := values.Get("error"); e != "" {
+		if desc := values.Get("error_description"); desc != "" {
+			return fmt.Errorf("%w: %s: %s", lib.Errors.AuthenticationFailed, e, desc)
+		}
+		return fmt.Errorf("%w: %s", lib.Errors.AuthenticationFailed, e)
+	} else if values.Get("code") == "" {
+		return fmt.Errorf("%w: missing code", lib.Errors.AuthenticationFailed)
+	}
+	ctx = gp.oauthCtx(ctx)
+	token, err := gp.authCfg.Exchange(ctx, values.Get("code"), oauth2.VerifierOption(verifier))
+	if err != nil {
+		return err
+	}
+	gp.useToken(token, gp.authCfg.TokenSource(context.WithoutCancel(ctx), token), true)
+	return nil
+}
+
+func reply(cb authCallback, err error) {
+	if cb.done != nil {
+		cb.done <- err
+	}
+}
+
+// Authenticate using stdin.
+func (gp *GotifyPlayer) AuthenticateStdin() error {
+	return gp.AuthenticateStdinCtx(context.Background())
+}
+
+// Authenticate using stdin.
+func (gp *GotifyPlayer) AuthenticateStdinCtx(ctx context.Context) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	callbacks := make(chan authCallback)
+	defer readStdin(ctx, cancel, callbacks)()
+	return gp.authorize(ctx, oauth2.GenerateVerifier(), gp.authUserMsgCallback, callbacks)
+}
+
+// readStdin sends the first non-empty line of stdin to callbacks as pasted redirect URL, cancelling ctx when stdin is closed.
+//
+// Stdin is read byte by byte so no input beyond that line is consumed, stop interrupts a pending read to hand stdin back to the application.
+func readStdin(ctx context.Context, cancel context.CancelCauseFunc, callbacks chan<- authCallback) (stop func()) {
+	stdin, release := openStdin()
+	go func() {
+		line, err := "", error(nil)
+		for err == nil && line == "" {
+			line, err = readLine(stdin)
+		}
+		if err != nil {
+			if ctx.Err() == nil {
+				cancel(fmt.Errorf("%w: %w", lib.Errors.AuthenticationFailed, err))
+			}
+			return
+		}
+		query := line
+		if _, q, ok := strings.Cut(line, "?"); ok {
+			query = q
+		}
+		values, _ := url.ParseQuery(query)
+		select {
+		case <-ctx.Done():
+		case callbacks <- authCallback{values: values, done: nil}:
+		}
+	}()
+	return release
+}
+
+// readLine reads a single line from r without reading ahead, returning it without surrounding whitespace.
+func readLine(r io.Reader) (string, error) {
+	line, b := []byte{}, make([]byte, 1)
+	for {
+		n, err := r.Read(b)
+		if n > 0 && b[0] == '\n' {
+			return strings.TrimSpace(string(line)), nil
+		} else if n > 0 {
+			line = append(line, b[0])
+		}
+		if errors.Is(err, io.EOF) && len(line) > 0 {
+			return strings.TrimSpace(string(line)), nil
+		} else if err != nil {
+			return "", err
+		}
+	}
+}
+
+// Authenticate using local http server.
+//
+// Listens on the host and path of the redirect URL at the given port, use 0 to use the port of the redirect URL (80 or 443 by scheme when it has none).
+func (gp *GotifyPlayer) AuthenticateHTTP(port uint16) error {
+	return gp.AuthenticateHTTPCtx(context.Background(), port)
+}
+
+// Authenticate using local http server.
+//
+// Listens on the host and path of the redirect URL at the given port, use 0 to use the port of the redirect URL (80 or 443 by scheme when it has none).
+func (gp *GotifyPlayer) AuthenticateHTTPCtx(ctx context.Context, port uint16) error {
+	redirect, err := url.Parse(gp.authCfg.RedirectURL)
+	if err != nil {
+		return err
+	}
+	p := redirect.Port()
+	if port != 0 {
+		p = strconv.FormatUint(uint64(port), 10)
+	} else if p == "" && redirect.Scheme == "https" {
+		p = "443"
+	} else if p == "" {
+		p = "80"
+	}
+	return gp.AuthenticateHTTPAddrCtx(ctx, net.JoinHostPort(redirect.Hostname(), p))
+}
+
+// Authenticate using local http server.
+//
+// Listens on addr at the path of the redirect URL, use an empty host (Ex: ":5050") to listen on all interfaces.
+func (gp *GotifyPlayer) AuthenticateHTTPAddr(addr string) error {
+	return gp.AuthenticateHTTPAddrCtx(context.Background(), addr)
+}
+
+// Authenticate using local http server.
+//
+// Listens on addr at the path of the redirect URL, use an empty host (Ex: ":5050") to listen on all interfaces.
+func (gp *GotifyPlayer) AuthenticateHTTPAddrCtx(ctx context.Context, addr string) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	state, callbacks := oauth2.GenerateVerifier(), make(chan authCallback)
+	stop, err := gp.serveCallbacks(ctx, cancel, addr, state, callbacks)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		cancel(nil) // Release handlers waiting on callbacks before shutting down, as shutdown waits for them.
+		stop()
+	}()
+	return gp.authorize(ctx, state, gp.authUserMsgCallback, callbacks)
+}
+
+// serveCallbacks listens on addr at the path of the redirect URL and sends every request for state to callbacks, answering it with a status page.
+//
+// Requests with another state (Ex: "/favicon.ico") are answered with `lib.Errors.InvalidState` right away.
+// Serve errors cancel ctx, stop shuts the server down and should be called after ctx is done, as it waits for pending requests.
+func (gp *GotifyPlayer) serveCallbacks(ctx context.Context, cancel context.CancelCauseFunc, addr, state string, callbacks chan<- authCallback) (stop func(), err error) {
+	redirect, err := url.Parse(gp.authCfg.RedirectURL)
+	if err != nil {
+		return nil, err
+	}
+	path := redirect.Path
+	if path == "" {
+		path = "/"
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("state") != state {
+			writeAuthPage(w, http.StatusBadRequest, lib.Errors.InvalidState)
+			return
+		}
+		done := make(chan error, 1)
+		select {
+		case callbacks <- authCallback{values: r.URL.Query(), done: done}:
+		case <-ctx.Done():
+			writeAuthPage(w, http.StatusServiceUnavailable, context.Cause(ctx))
+			return
+		case <-r.Context().Done():
+			return
+		}
+		if err := <-done; err != nil {
+			writeAuthPage(w, http.StatusBadRequest, err)
+			return
+		}
+		writeAuthPage(w, http.StatusOK, nil)
+	})
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: time.Second * 10}
+	go func() {
+		if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			cancel(err)
+		}
+	}()
+	return func() {
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Second*5)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			_ = server.Close()
+		}
+	}, nil
+}
+
+func writeAuthPage(w http.ResponseWriter, status int, err error) {
+	title, msg := "Login successful", "You can close this window and return to the application."
+	if err != nil {
+		title, msg = "Login failed", err.Error()
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintf(w, authPage, html.EscapeString(title), html.EscapeString(title), html.EscapeString(msg))
+}
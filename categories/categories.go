@@ -1,7 +1,9 @@
 package categories
 
 import (
+	"context"
 	"encoding/json"
+	"iter"
 	"strconv"
 
 	"github.com/HandyGold75/gotify/lib"
@@ -9,40 +11,50 @@ import (
 
 type (
 	Categories struct {
-		Send   func(method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)
+		Send   func(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)
 		Locale string // an ISO 639-1 language code, http://en.wikipedia.org/wiki/ISO_639-1 and an ISO 3166-1 alpha-2 country code, http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2 joined by an underscore.
 	}
-
-	getSeveralBrowseCategories struct {
-		Categories struct {
-			lib.ItemsHeaders
-			Items []lib.Categorie `json:"items"`
-		} `json:"categories"`
-	}
-
-	getSingleBrowseCategory lib.Categorie
 )
 
-func New(send func(method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)) Categories {
+func New(send func(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)) Categories {
 	return Categories{Send: send, Locale: ""}
 }
 
-func (s *Categories) GetSeveralBrowseCategories(limit, offset int) (getSeveralBrowseCategories, error) {
-	res, err := s.Send(lib.GET, "browse/categories", [][2]string{{"locale", s.Locale}, {"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
+func (s *Categories) GetSeveralBrowseCategories(limit, offset int) (lib.Categories, error) {
+	return s.GetSeveralBrowseCategoriesCtx(context.Background(), limit, offset)
+}
+
+func (s *Categories) GetSeveralBrowseCategoriesCtx(ctx context.Context, limit, offset int) (lib.Categories, error) {
+	res, err := s.Send(ctx, lib.GET, "browse/categories", [][2]string{{"locale", s.Locale}, {"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
 	if err != nil {
-		return getSeveralBrowseCategories{}, err
+		return lib.Categories{}, err
 	}
-	data := getSeveralBrowseCategories{}
+	data := lib.Categories{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
 
-func (s *Categories) GetSingleBrowseCategory(id string) (getSingleBrowseCategory, error) {
-	res, err := s.Send(lib.GET, "browse/categories/"+id, [][2]string{{"locale", s.Locale}}, []byte{})
+func (s *Categories) AllBrowseCategories() iter.Seq2[lib.Categorie, error] {
+	return s.AllBrowseCategoriesCtx(context.Background())
+}
+
+func (s *Categories) AllBrowseCategoriesCtx(ctx context.Context) iter.Seq2[lib.Categorie, error] {
+	return lib.Paginate(func(offset int) ([]lib.Categorie, lib.ItemsHeaders, error) {
+		data, err := s.GetSeveralBrowseCategoriesCtx(ctx, 50, offset)
+		return data.Categories.Items, data.Categories.ItemsHeaders, err
+	})
+}
+
+func (s *Categories) GetSingleBrowseCategory(id string) (lib.Categorie, error) {
+	return s.GetSingleBrowseCategoryCtx(context.Background(), id)
+}
+
+func (s *Categories) GetSingleBrowseCategoryCtx(ctx context.Context, id string) (lib.Categorie, error) {
+	res, err := s.Send(ctx, lib.GET, "browse/categories/"+id, [][2]string{{"locale", s.Locale}}, []byte{})
 	if err != nil {
-		return getSingleBrowseCategory{}, err
+		return lib.Categorie{}, err
 	}
-	data := getSingleBrowseCategory{}
+	data := lib.Categorie{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
@@ -1,6 +1,7 @@
 package chapters
 
 import (
+	"context"
 	"encoding/json"
 	"strings"
 
@@ -9,35 +10,46 @@ import (
 
 type (
 	Chapters struct {
-		Send   func(method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)
-		Market string // An ISO 3166-1 alpha-2 country code, https://en.wikipedia.org/wiki/ISO_3166-1_alpha-2
+		Send        func(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)
+		Market      string // An ISO 3166-1 alpha-2 country code, https://en.wikipedia.org/wiki/ISO_3166-1_alpha-2
+		Concurrency int    // Maximum batches sent at once when the IDs exceed the Spotify batch limit, values below 2 send batches sequentially.
 	}
-
-	getAChapter lib.ChapterObject
-
-	getSeveralChapters lib.Chapters
 )
 
-func New(send func(method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)) Chapters {
+func New(send func(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)) Chapters {
 	return Chapters{Send: send, Market: ""}
 }
 
-func (s *Chapters) GetAChapter(id string) (getAChapter, error) {
-	res, err := s.Send(lib.GET, "chapters/"+id, [][2]string{{"market", s.Market}}, []byte{})
+func (s *Chapters) GetAChapter(id string) (lib.ChapterObject, error) {
+	return s.GetAChapterCtx(context.Background(), id)
+}
+
+func (s *Chapters) GetAChapterCtx(ctx context.Context, id string) (lib.ChapterObject, error) {
+	res, err := s.Send(ctx, lib.GET, "chapters/"+id, [][2]string{{"market", s.Market}}, []byte{})
 	if err != nil {
-		return getAChapter{}, err
+		return lib.ChapterObject{}, err
 	}
-	data := getAChapter{}
+	data := lib.ChapterObject{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
 
-func (s *Chapters) GetSeveralChapters(ids []string) (getSeveralChapters, error) {
-	res, err := s.Send(lib.GET, "chapters", [][2]string{{"market", s.Market}, {"ids", strings.Join(ids, ",")}}, []byte{})
+func (s *Chapters) GetSeveralChapters(ids []string) (lib.Chapters, error) {
+	return s.GetSeveralChaptersCtx(context.Background(), ids)
+}
+
+func (s *Chapters) GetSeveralChaptersCtx(ctx context.Context, ids []string) (lib.Chapters, error) {
+	items, err := lib.Batch(ids, 50, s.Concurrency, func(ids []string) ([]lib.ChapterObject, error) {
+		res, err := s.Send(ctx, lib.GET, "chapters", [][2]string{{"market", s.Market}, {"ids", strings.Join(ids, ",")}}, []byte{})
+		if err != nil {
+			return nil, err
+		}
+		data := lib.Chapters{}
+		err = json.Unmarshal(res, &data)
+		return data.Chapters, err
+	})
 	if err != nil {
-		return getSeveralChapters{}, err
+		return lib.Chapters{}, err
 	}
-	data := getSeveralChapters{}
-	err = json.Unmarshal(res, &data)
-	return data, err
+	return lib.Chapters{Chapters: items}, nil
 }
@@ -1,7 +1,9 @@
 package episodes
 
 import (
+	"context"
 	"encoding/json"
+	"iter"
 	"strconv"
 	"strings"
 
@@ -10,87 +12,131 @@ import (
 
 type (
 	Episodes struct {
-		Send   func(method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)
-		Market string // An ISO 3166-1 alpha-2 country code, https://en.wikipedia.org/wiki/ISO_3166-1_alpha-2
-	}
-
-	getEpisode lib.EpisodeObject
-
-	getSeveralEpisodes lib.Episodes
-
-	getUsersSavedEpisodes struct {
-		lib.ItemsHeaders
-		Items []struct {
-			AddedAt string `json:"added_at"`
-			lib.Episode
-		} `json:"items"`
+		Send        func(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)
+		Market      string // An ISO 3166-1 alpha-2 country code, https://en.wikipedia.org/wiki/ISO_3166-1_alpha-2
+		Concurrency int    // Maximum batches sent at once when the IDs exceed the Spotify batch limit, values below 2 send batches sequentially.
 	}
 )
 
-func New(send func(method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)) Episodes {
+func New(send func(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)) Episodes {
 	return Episodes{Send: send, Market: ""}
 }
 
 // Scopes: `ScopeUserReadPlaybackPosition`
-func (s *Episodes) GetEpisode(id string) (getEpisode, error) {
-	res, err := s.Send(lib.GET, "episodes/"+id, [][2]string{{"market", s.Market}}, []byte{})
+func (s *Episodes) GetEpisode(id string) (lib.EpisodeObject, error) {
+	return s.GetEpisodeCtx(context.Background(), id)
+}
+
+// Scopes: `ScopeUserReadPlaybackPosition`
+func (s *Episodes) GetEpisodeCtx(ctx context.Context, id string) (lib.EpisodeObject, error) {
+	res, err := s.Send(ctx, lib.GET, "episodes/"+id, [][2]string{{"market", s.Market}}, []byte{})
 	if err != nil {
-		return getEpisode{}, err
+		return lib.EpisodeObject{}, err
 	}
-	data := getEpisode{}
+	data := lib.EpisodeObject{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
 
 // Scopes: `ScopeUserReadPlaybackPosition`
-func (s *Episodes) GetSeveralEpisodes(ids []string) (getSeveralEpisodes, error) {
-	res, err := s.Send(lib.GET, "episodes", [][2]string{{"market", s.Market}, {"ids", strings.Join(ids, ",")}}, []byte{})
+func (s *Episodes) GetSeveralEpisodes(ids []string) (lib.Episodes, error) {
+	return s.GetSeveralEpisodesCtx(context.Background(), ids)
+}
+
+// Scopes: `ScopeUserReadPlaybackPosition`
+func (s *Episodes) GetSeveralEpisodesCtx(ctx context.Context, ids []string) (lib.Episodes, error) {
+	items, err := lib.Batch(ids, 50, s.Concurrency, func(ids []string) ([]lib.EpisodeObject, error) {
+		res, err := s.Send(ctx, lib.GET, "episodes", [][2]string{{"market", s.Market}, {"ids", strings.Join(ids, ",")}}, []byte{})
+		if err != nil {
+			return nil, err
+		}
+		data := lib.Episodes{}
+		err = json.Unmarshal(res, &data)
+		return data.Episodes, err
+	})
 	if err != nil {
-		return getSeveralEpisodes{}, err
+		return lib.Episodes{}, err
 	}
-	data := getSeveralEpisodes{}
-	err = json.Unmarshal(res, &data)
-	return data, err
+	return lib.Episodes{Episodes: items}, nil
+}
+
+// Scopes: `ScopeUserLibraryRead`, `ScopeUserReadPlaybackPosition`
+func (s *Episodes) GetUsersSavedEpisodes(limit, offset int) (lib.Paging[lib.SavedEpisode], error) {
+	return s.GetUsersSavedEpisodesCtx(context.Background(), limit, offset)
 }
 
 // Scopes: `ScopeUserLibraryRead`, `ScopeUserReadPlaybackPosition`
-func (s *Episodes) GetUsersSavedEpisodes(limit, offset int) (getUsersSavedEpisodes, error) {
-	res, err := s.Send(lib.GET, "me/episodes", [][2]string{{"market", s.Market}, {"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
+func (s *Episodes) GetUsersSavedEpisodesCtx(ctx context.Context, limit, offset int) (lib.Paging[lib.SavedEpisode], error) {
+	res, err := s.Send(ctx, lib.GET, "me/episodes", [][2]string{{"market", s.Market}, {"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
 	if err != nil {
-		return getUsersSavedEpisodes{}, err
+		return lib.Paging[lib.SavedEpisode]{}, err
 	}
-	data := getUsersSavedEpisodes{}
+	data := lib.Paging[lib.SavedEpisode]{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
 
+// Scopes: `ScopeUserLibraryRead`, `ScopeUserReadPlaybackPosition`
+func (s *Episodes) AllUsersSavedEpisodes() iter.Seq2[lib.SavedEpisode, error] {
+	return s.AllUsersSavedEpisodesCtx(context.Background())
+}
+
+// Scopes: `ScopeUserLibraryRead`, `ScopeUserReadPlaybackPosition`
+func (s *Episodes) AllUsersSavedEpisodesCtx(ctx context.Context) iter.Seq2[lib.SavedEpisode, error] {
+	return lib.Paginate(func(offset int) ([]lib.SavedEpisode, lib.ItemsHeaders, error) {
+		data, err := s.GetUsersSavedEpisodesCtx(ctx, 50, offset)
+		return data.Items, data.ItemsHeaders, err
+	})
+}
+
 // Scopes: `ScopeUserLibraryModify`
 func (s *Episodes) SaveEpisodesForCurrentUser(ids []string) error {
-	body, err := json.Marshal(map[string]any{"ids": ids})
-	if err != nil {
+	return s.SaveEpisodesForCurrentUserCtx(context.Background(), ids)
+}
+
+// Scopes: `ScopeUserLibraryModify`
+func (s *Episodes) SaveEpisodesForCurrentUserCtx(ctx context.Context, ids []string) error {
+	return lib.BatchDo(ids, 50, s.Concurrency, func(ids []string) error {
+		body, err := json.Marshal(map[string]any{"ids": ids})
+		if err != nil {
+			return err
+		}
+		_, err = s.Send(ctx, lib.PUT, "me/episodes", [][2]string{}, body)
 		return err
-	}
-	_, err = s.Send(lib.PUT, "me/episodes", [][2]string{}, body)
-	return err
+	})
 }
 
 // Scopes: `ScopeUserLibraryModify`
 func (s *Episodes) RemoveUsersSavedEpisodes(ids []string) error {
-	body, err := json.Marshal(map[string]any{"ids": ids})
-	if err != nil {
+	return s.RemoveUsersSavedEpisodesCtx(context.Background(), ids)
+}
+
+// Scopes: `ScopeUserLibraryModify`
+func (s *Episodes) RemoveUsersSavedEpisodesCtx(ctx context.Context, ids []string) error {
+	return lib.BatchDo(ids, 50, s.Concurrency, func(ids []string) error {
+		body, err := json.Marshal(map[string]any{"ids": ids})
+		if err != nil {
+			return err
+		}
+		_, err = s.Send(ctx, lib.DELETE, "me/episodes", [][2]string{}, body)
 		return err
-	}
-	_, err = s.Send(lib.DELETE, "me/episodes", [][2]string{}, body)
-	return err
+	})
 }
 
 // Scopes: `ScopeUserLibraryRead`
 func (s *Episodes) CheckUsersSavedEpisodes(ids []string) ([]bool, error) {
-	res, err := s.Send(lib.GET, "me/episodes/contains", [][2]string{{"ids", strings.Join(ids, ",")}}, []byte{})
-	if err != nil {
-		return []bool{}, err
-	}
-	data := []bool{}
-	err = json.Unmarshal(res, &data)
-	return data, err
+	return s.CheckUsersSavedEpisodesCtx(context.Background(), ids)
+}
+
+// Scopes: `ScopeUserLibraryRead`
+func (s *Episodes) CheckUsersSavedEpisodesCtx(ctx context.Context, ids []string) ([]bool, error) {
+	return lib.Batch(ids, 50, s.Concurrency, func(ids []string) ([]bool, error) {
+		res, err := s.Send(ctx, lib.GET, "me/episodes/contains", [][2]string{{"ids", strings.Join(ids, ",")}}, []byte{})
+		if err != nil {
+			return []bool{}, err
+		}
+		data := []bool{}
+		err = json.Unmarshal(res, &data)
+		return data, err
+	})
 }
@@ -0,0 +1,79 @@
+package genres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/HandyGold75/gotify/lib"
+)
+
+type (
+	Genres struct {
+		Send func(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)
+
+		mu    sync.Mutex
+		cache []string
+	}
+)
+
+func New(send func(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)) Genres {
+	return Genres{Send: send, mu: sync.Mutex{}, cache: []string{}}
+}
+
+func (s *Genres) GetAvailableGenreSeeds() (lib.GenreSeeds, error) {
+	return s.GetAvailableGenreSeedsCtx(context.Background())
+}
+
+func (s *Genres) GetAvailableGenreSeedsCtx(ctx context.Context) (lib.GenreSeeds, error) {
+	res, err := s.Send(ctx, lib.GET, "recommendations/available-genre-seeds", [][2]string{}, []byte{})
+	if err != nil {
+		return lib.GenreSeeds{}, err
+	}
+	data := lib.GenreSeeds{}
+	if err := json.Unmarshal(res, &data); err != nil {
+		return lib.GenreSeeds{}, err
+	}
+	s.mu.Lock()
+	s.cache = slices.Clone(data.Genres)
+	s.mu.Unlock()
+	return data, nil
+}
+
+// Cached returns the genre seeds cached by the last successful call to `GetAvailableGenreSeeds`, fetching them if nothing is cached yet.
+func (s *Genres) Cached() ([]string, error) {
+	return s.CachedCtx(context.Background())
+}
+
+// CachedCtx returns the genre seeds cached by the last successful call to `GetAvailableGenreSeeds`, fetching them if nothing is cached yet.
+func (s *Genres) CachedCtx(ctx context.Context) ([]string, error) {
+	s.mu.Lock()
+	cache := slices.Clone(s.cache)
+	s.mu.Unlock()
+	if len(cache) > 0 {
+		return cache, nil
+	}
+	data, err := s.GetAvailableGenreSeedsCtx(ctx)
+	return data.Genres, err
+}
+
+// Validate checks all genres against the cached genre seeds, returns `lib.Errors.InvalidGenre` on the first unknown genre.
+func (s *Genres) Validate(genres ...string) error {
+	return s.ValidateCtx(context.Background(), genres...)
+}
+
+// ValidateCtx checks all genres against the cached genre seeds, returns `lib.Errors.InvalidGenre` on the first unknown genre.
+func (s *Genres) ValidateCtx(ctx context.Context, genres ...string) error {
+	seeds, err := s.CachedCtx(ctx)
+	if err != nil {
+		return err
+	}
+	for _, genre := range genres {
+		if !slices.Contains(seeds, genre) {
+			return fmt.Errorf("%w: %s", lib.Errors.InvalidGenre, genre)
+		}
+	}
+	return nil
+}
@@ -3,3 +3,5 @@ module github.com/HandyGold75/gotify
 go 1.25.0
 
 require golang.org/x/oauth2 v0.30.0
+
+require github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
@@ -1,17 +1,18 @@
 package gotify
 
 import (
-	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net/http"
-	"os"
 	"slices"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/HandyGold75/gotify/albums"
@@ -20,25 +21,40 @@ import (
 	"github.com/HandyGold75/gotify/categories"
 	"github.com/HandyGold75/gotify/chapters"
 	"github.com/HandyGold75/gotify/episodes"
+	"github.com/HandyGold75/gotify/genres"
 	"github.com/HandyGold75/gotify/lib"
 	"github.com/HandyGold75/gotify/markets"
 	"github.com/HandyGold75/gotify/player"
 	"github.com/HandyGold75/gotify/playlists"
 	"github.com/HandyGold75/gotify/search"
+	"github.com/HandyGold75/gotify/shows"
 	"github.com/HandyGold75/gotify/tracks"
 	"github.com/HandyGold75/gotify/users"
 	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
 )
 
 type (
 	scope string // https://developer.spotify.com/documentation/web-api/concepts/scopes
 
 	GotifyPlayer struct {
-		URL string
+		URL   string
+		Retry RetryPolicy
+
+		AuthTimeout    time.Duration                        // Maximum time to wait for the user to login, zero waits indefinitely.
+		TokenStore     TokenStore                           // Persists user tokens after authenticating and on every refresh, client credentials tokens are not persisted, may be nil.
+		OnTokenRefresh func(token *oauth2.Token, err error) // Called after authenticating and on every refresh with the result of `TokenStore.Save`, may be nil.
+
+		EnqueueInterval time.Duration // Delay between requests of `EnqueueURIs`, `EnqueueAlbum` and `EnqueuePlaylist` to stay within the rate limit.
 
 		authCfg             oauth2.Config
 		authUserMsgCallback func(url string)
-		cl                  *http.Client
+		authQRMsgCallback   func(url, qr string)
+		baseCl              *http.Client
+		cl                  atomic.Pointer[http.Client]
+		granted             atomic.Pointer[[]scope]
+		product             atomic.Pointer[string]
+		productErr          atomic.Pointer[productFailure]
 
 		Albums     albums.Albums
 		Artists    artists.Artists
@@ -46,19 +62,36 @@ type (
 		Categories categories.Categories
 		Chapters   chapters.Chapters
 		Episodes   episodes.Episodes
+		Genres     genres.Genres
 		Markets    markets.Markets
 		Player     player.Player
 		Playlists  playlists.Playlists
 		Search     search.Search
-		// Shows      shows.Shows
-		Tracks tracks.Tracks
-		Users  users.Users
+		Shows      shows.Shows
+		Tracks     tracks.Tracks
+		Users      users.Users
+
+		Devices player.DeviceManager
+		Watcher player.Watcher
 	}
 
+	// RetryPolicy controls how `Send` retries requests that failed with status 429, or with status 5xx for idempotent methods (GET, PUT and DELETE).
+	//
+	// POST requests are not retried on 5xx as Spotify may have applied them already, Ex: adding an item to the queue twice.
+	RetryPolicy struct {
+		MaxAttempts int                                               // Maximum attempts per request, values below 2 disable retrying.
+		BaseDelay   time.Duration                                     // Delay before the first retry, doubled on every following retry and jittered.
+		MaxDelay    time.Duration                                     // Upper bound for the delay between retries, a `Retry-After` header exceeding it returns the error instead of waiting.
+		OnRetry     func(attempt int, delay time.Duration, err error) // Called before sleeping for every retry, may be nil.
+	}
+
+	APIError = lib.APIError
+
 	errorResponse struct {
 		Error struct {
 			Status  int    `json:"status"`
 			Message string `json:"message"`
+			Reason  string `json:"reason"`
 		} `json:"error"`
 	}
 )
@@ -101,13 +134,13 @@ const (
 	ScopeSoaCreatePartner          scope = "soa-create-partner"          // Create new partners, platform partners only
 )
 
-func NewGotifyPlayer(clientID, redirectURL string, scopes ...scope) *GotifyPlayer {
-	scps := []string{}
-	for _, scp := range scopes {
-		scps = append(scps, string(scp))
-	}
+func NewGotifyPlayer(clientID, redirectURL string, opts ...Option) *GotifyPlayer {
 	gp := &GotifyPlayer{
-		URL: "https://api.spotify.com/v1",
+		URL:   "https://api.spotify.com/v1",
+		Retry: RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: time.Second * 30, OnRetry: nil},
+
+		EnqueueInterval: time.Millisecond * 250,
+
 		authCfg: oauth2.Config{
 			ClientID: clientID,
 			Endpoint: oauth2.Endpoint{
@@ -115,112 +148,90 @@ func NewGotifyPlayer(clientID, redirectURL string, scopes ...scope) *GotifyPlaye
 				TokenURL: "https://accounts.spotify.com/api/token",
 			},
 			RedirectURL: redirectURL,
-			Scopes:      scps,
+			Scopes:      []string{},
 		},
-		authUserMsgCallback: func(url string) { fmt.Print("\r\nLogin: " + url + "\r\nPaste: ") },
-		cl:                  http.DefaultClient,
+		authUserMsgCallback: nil,
+		authQRMsgCallback:   nil,
+		baseCl:              http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt.apply(gp)
+	}
+	gp.cl.Store(gp.baseCl)
+	if gp.authUserMsgCallback == nil {
+		gp.authUserMsgCallback = func(url string) { fmt.Print("\r\nLogin: " + url + "\r\nPaste: ") }
+		if gp.authQRMsgCallback == nil {
+			gp.authQRMsgCallback = func(url, qr string) {
+				fmt.Print("\r\n" + strings.ReplaceAll(qr, "\n", "\r\n") + "\r\nLogin: " + url + "\r\nPaste: ")
+			}
+		}
 	}
 
-	gp.Albums = albums.New(gp.Send)
-	gp.Artists = artists.New(gp.Send)
-	gp.Audiobooks = audiobooks.New(gp.Send)
-	gp.Categories = categories.New(gp.Send)
-	gp.Chapters = chapters.New(gp.Send)
-	gp.Episodes = episodes.New(gp.Send)
-	gp.Markets = markets.New(gp.Send)
-	gp.Player = player.New(gp.Send)
-	gp.Playlists = playlists.New(gp.Send)
-	gp.Search = search.New(gp.Send)
-	// gp.Shows = shows.New(gp.Send)
-	gp.Tracks = tracks.New(gp.Send)
-	gp.Users = users.New(gp.Send)
+	gp.Albums = albums.New(gp.SendCtx)
+	gp.Artists = artists.New(gp.SendCtx)
+	gp.Audiobooks = audiobooks.New(gp.SendCtx)
+	gp.Categories = categories.New(gp.SendCtx)
+	gp.Chapters = chapters.New(gp.SendCtx)
+	gp.Episodes = episodes.New(gp.SendCtx)
+	gp.Genres = genres.New(gp.SendCtx)
+	gp.Markets = markets.New(gp.SendCtx)
+	gp.Player = player.New(gp.SendCtx)
+	gp.Devices = player.NewDeviceManager(&gp.Player)
+	gp.Watcher = player.NewWatcher(&gp.Player)
+	gp.Playlists = playlists.New(gp.SendCtx)
+	gp.Search = search.New(gp.SendCtx)
+	gp.Shows = shows.New(gp.SendCtx)
+	gp.Tracks = tracks.New(gp.SendCtx)
+	gp.Users = users.New(gp.SendCtx)
 
 	return gp
 }
 
-// Authenticate using stdin.
-func (gp *GotifyPlayer) AuthenticateStdin() error {
-	verifier, state, ch := oauth2.GenerateVerifier(), oauth2.GenerateVerifier(), make(chan string)
-	go func() {
-		defer close(ch)
-		msg := ""
-		for msg == "" {
-			m, err := bufio.NewReader(os.Stdin).ReadString('\n')
-			if err != nil {
-				return
-			}
-			msgSplit := strings.Split(strings.TrimSuffix(m, "\n"), "?")
-			msg = msgSplit[len(msgSplit)-1]
-		}
-		ch <- msg
-	}()
+// Authenticate using a token.
+func (gp *GotifyPlayer) AuthenticateToken(token *oauth2.Token) error {
+	return gp.AuthenticateTokenCtx(context.Background(), token)
+}
 
-	gp.authUserMsgCallback(gp.authCfg.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.S256ChallengeOption(verifier)))
-	msg, ok := <-ch
-	if !ok {
-		return errors.New("failed authentication")
-	}
-	code, actualState := "", ""
-	for pair := range strings.SplitSeq(msg, "&") {
-		if strings.HasPrefix(pair, "code=") {
-			code = strings.Replace(pair, "code=", "", 1)
-		} else if strings.HasPrefix(pair, "state=") {
-			actualState = strings.Replace(pair, "state=", "", 1)
-		}
-	}
-	if code == "" || actualState != state {
-		return errors.New("failed authentication")
-	}
-	token, err := gp.authCfg.Exchange(context.Background(), code, oauth2.VerifierOption(verifier))
+// Authenticate using a token.
+func (gp *GotifyPlayer) AuthenticateTokenCtx(ctx context.Context, token *oauth2.Token) error {
+	ctx = gp.oauthCtx(ctx)
+	token.Expiry = token.Expiry.Add(-(time.Hour * 2))
+	token, err := gp.authCfg.TokenSource(ctx, token).Token()
 	if err != nil {
 		return err
 	}
-	gp.cl = gp.authCfg.Client(context.Background(), token)
+	gp.useToken(token, gp.authCfg.TokenSource(context.WithoutCancel(ctx), token), true)
 	return nil
 }
 
-// Authenticate using local http server.
-func (gp *GotifyPlayer) AuthenticateHTTP(port uint16) error {
-	verifier, state, ch := oauth2.GenerateVerifier(), oauth2.GenerateVerifier(), make(chan string)
-	http.HandleFunc("/spotify_auth_callback", func(w http.ResponseWriter, r *http.Request) {
-		defer close(ch)
-		values := r.URL.Query()
-		if e := values.Get("error"); e != "" || values.Get("state") != string(state) || r.FormValue("state") != string(state) {
-			return
-		}
-		ch <- values.Get("code")
-	})
-	server := &http.Server{Addr: ":" + strconv.FormatUint(uint64(port), 10), Handler: nil}
-	go func() { _ = server.ListenAndServe() }()
-	defer server.Close()
-
-	gp.authUserMsgCallback(gp.authCfg.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.S256ChallengeOption(verifier)))
-	code, ok := <-ch
-	if !ok {
-		return errors.New("failed authentication")
-	}
-	token, err := gp.authCfg.Exchange(context.Background(), code)
-	if err != nil {
-		return err
-	}
-	gp.cl = gp.authCfg.Client(context.Background(), token)
-	return nil
+// Authenticate using the client credentials flow, only endpoints without user data can be used.
+//
+// The token is refreshed automatically when it expires, it is not saved to `TokenStore` as it can not be used to authenticate a user later.
+func (gp *GotifyPlayer) AuthenticateClientCredentials(clientSecret string) error {
+	return gp.AuthenticateClientCredentialsCtx(context.Background(), clientSecret)
 }
 
-// Authenticate using a token.
-func (gp *GotifyPlayer) AuthenticateToken(token *oauth2.Token) error {
-	token.Expiry = token.Expiry.Add(-(time.Hour * 2))
-	token, err := gp.authCfg.TokenSource(context.Background(), token).Token()
+// Authenticate using the client credentials flow, only endpoints without user data can be used.
+//
+// The token is refreshed automatically when it expires, it is not saved to `TokenStore` as it can not be used to authenticate a user later.
+func (gp *GotifyPlayer) AuthenticateClientCredentialsCtx(ctx context.Context, clientSecret string) error {
+	ctx = gp.oauthCtx(ctx)
+	cfg := clientcredentials.Config{
+		ClientID:     gp.authCfg.ClientID,
+		ClientSecret: clientSecret,
+		TokenURL:     gp.authCfg.Endpoint.TokenURL,
+	}
+	token, err := cfg.Token(ctx)
 	if err != nil {
 		return err
 	}
-	gp.cl = gp.authCfg.Client(context.Background(), token)
+	gp.useToken(token, cfg.TokenSource(context.WithoutCancel(ctx)), false)
 	return nil
 }
 
 // Token get current active token.
 func (gp *GotifyPlayer) Token() (*oauth2.Token, error) {
-	transport, ok := gp.cl.Transport.(*oauth2.Transport)
+	transport, ok := gp.cl.Load().Transport.(*oauth2.Transport)
 	if !ok {
 		return nil, errors.New("client not backed by oauth2 transport")
 	}
@@ -228,6 +239,10 @@ func (gp *GotifyPlayer) Token() (*oauth2.Token, error) {
 }
 
 func (gp *GotifyPlayer) Send(method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error) {
+	return gp.SendCtx(context.Background(), method, action, options, body)
+}
+
+func (gp *GotifyPlayer) SendCtx(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error) {
 	opts := ""
 	for _, opt := range slices.DeleteFunc(options, func(o [2]string) bool { return o[0] == "" || o[1] == "" }) {
 		if opts != "" {
@@ -238,8 +253,47 @@ func (gp *GotifyPlayer) Send(method lib.HTTPMethod, action string, options [][2]
 	if opts != "" {
 		opts = "?" + opts
 	}
+	if err := gp.checkScopes(method, action); err != nil {
+		return []byte{}, err
+	}
+	if err := gp.checkPremium(ctx, method, action); err != nil {
+		return []byte{}, err
+	}
 
-	req, err := http.NewRequest(string(method), strings.TrimSuffix(gp.URL+"/"+action, "/")+opts, strings.NewReader(string(body[:])))
+	for attempt := 1; ; attempt++ {
+		res, err := gp.do(ctx, method, action, strings.TrimSuffix(gp.URL+"/"+action, "/")+opts, body)
+		gp.notePremium(err)
+		apiErr := &APIError{}
+		if err == nil || attempt >= gp.Retry.MaxAttempts || !errors.As(err, &apiErr) || !retryable(method, apiErr.Status) || apiErr.RetryAfter > gp.Retry.MaxDelay {
+			return res, err
+		}
+
+		delay := apiErr.RetryAfter
+		if delay <= 0 {
+			delay = min(gp.Retry.MaxDelay, gp.Retry.BaseDelay<<min(attempt-1, 16))
+			delay = delay/2 + rand.N(delay/2+1)
+		}
+		if gp.Retry.OnRetry != nil {
+			gp.Retry.OnRetry(attempt, delay, err)
+		}
+		select {
+		case <-ctx.Done():
+			return []byte{}, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// retryable reports whether a request failing with status may be sent again, 5xx responses are only retried for idempotent methods.
+func retryable(method lib.HTTPMethod, status int) bool {
+	if status == http.StatusTooManyRequests {
+		return true
+	}
+	return status >= http.StatusInternalServerError && (method == lib.GET || method == lib.PUT || method == lib.DELETE)
+}
+
+func (gp *GotifyPlayer) do(ctx context.Context, method lib.HTTPMethod, action, url string, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, string(method), url, bytes.NewReader(body))
 	if err != nil {
 		return []byte{}, err
 	}
@@ -247,7 +301,7 @@ func (gp *GotifyPlayer) Send(method lib.HTTPMethod, action string, options [][2]
 		req.Header.Add("Content-Type", http.DetectContentType(body))
 	}
 
-	resp, err := gp.cl.Do(req)
+	resp, err := gp.cl.Load().Do(req)
 	if err != nil {
 		return []byte{}, err
 	}
@@ -257,9 +311,19 @@ func (gp *GotifyPlayer) Send(method lib.HTTPMethod, action string, options [][2]
 	if err != nil {
 		return []byte{}, err
 	}
+	if resp.StatusCode == http.StatusNoContent {
+		return []byte{}, nil
+	} else if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return res, nil
+	}
+
+	apiErr := &APIError{Status: resp.StatusCode, Message: http.StatusText(resp.StatusCode), Method: method, Path: action}
+	if sec, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		apiErr.RetryAfter = time.Duration(sec) * time.Second
+	}
 	data := errorResponse{}
-	if err := json.Unmarshal(res, &data); err == nil && data.Error.Status != 0 {
-		return []byte{}, errors.New(strconv.Itoa(data.Error.Status) + ": " + data.Error.Message)
+	if err := json.Unmarshal(res, &data); err == nil && data.Error.Message != "" {
+		apiErr.Message, apiErr.Reason = data.Error.Message, data.Error.Reason
 	}
-	return res, nil
+	return []byte{}, apiErr
 }
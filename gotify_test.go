@@ -0,0 +1,185 @@
+package gotify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/HandyGold75/gotify/lib"
+)
+
+// newTestPlayer returns a player sending API requests to handler, retrying quickly.
+func newTestPlayer(t *testing.T, handler http.HandlerFunc) *GotifyPlayer {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	gp := NewGotifyPlayer("id", "http://127.0.0.1/callback", WithBaseURL(srv.URL))
+	gp.Retry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond * 50, OnRetry: nil}
+	return gp
+}
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		method lib.HTTPMethod
+		status int
+		want   bool
+	}{
+		{method: lib.GET, status: http.StatusTooManyRequests, want: true},
+		{method: lib.POST, status: http.StatusTooManyRequests, want: true},
+		{method: lib.GET, status: http.StatusInternalServerError, want: true},
+		{method: lib.PUT, status: http.StatusBadGateway, want: true},
+		{method: lib.DELETE, status: http.StatusServiceUnavailable, want: true},
+		{method: lib.POST, status: http.StatusBadGateway, want: false},
+		{method: lib.GET, status: http.StatusNotFound, want: false},
+		{method: lib.PUT, status: http.StatusBadRequest, want: false},
+	}
+	for _, tt := range tests {
+		if got := retryable(tt.method, tt.status); got != tt.want {
+			t.Errorf("retryable(%s, %d) = %v, want %v", tt.method, tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestSendRetry(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      lib.HTTPMethod
+		statuses    []int  // Status of every response, the last one repeats.
+		retryAfter  string // Retry-After header of error responses.
+		maxAttempts int
+		wantCalls   int32
+		wantStatus  int // Status of the returned `APIError`, 0 for success.
+	}{
+		{name: "429 retried for POST", method: lib.POST, statuses: []int{429, 200}, maxAttempts: 3, wantCalls: 2},
+		{name: "5xx retried for GET", method: lib.GET, statuses: []int{500, 200}, maxAttempts: 3, wantCalls: 2},
+		{name: "5xx retried for PUT", method: lib.PUT, statuses: []int{502, 200}, maxAttempts: 3, wantCalls: 2},
+		{name: "5xx retried for DELETE", method: lib.DELETE, statuses: []int{503, 200}, maxAttempts: 3, wantCalls: 2},
+		{name: "5xx not retried for POST", method: lib.POST, statuses: []int{502, 200}, maxAttempts: 3, wantCalls: 1, wantStatus: 502},
+		{name: "4xx not retried", method: lib.GET, statuses: []int{404, 200}, maxAttempts: 3, wantCalls: 1, wantStatus: 404},
+		{name: "attempts exhausted", method: lib.GET, statuses: []int{503}, maxAttempts: 3, wantCalls: 3, wantStatus: 503},
+		{name: "retry after within max delay", method: lib.GET, statuses: []int{429, 200}, retryAfter: "0", maxAttempts: 3, wantCalls: 2},
+		{name: "retry after beyond max delay", method: lib.GET, statuses: []int{429, 200}, retryAfter: "60", maxAttempts: 3, wantCalls: 1, wantStatus: 429},
+		{name: "single attempt", method: lib.GET, statuses: []int{429, 200}, maxAttempts: 1, wantCalls: 1, wantStatus: 429},
+		{name: "zero attempts", method: lib.GET, statuses: []int{429, 200}, maxAttempts: 0, wantCalls: 1, wantStatus: 429},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := atomic.Int32{}
+			gp := newTestPlayer(t, func(w http.ResponseWriter, r *http.Request) {
+				status := tt.statuses[min(int(calls.Add(1)), len(tt.statuses))-1]
+				if status >= 400 && tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(status)
+			})
+			gp.Retry.MaxAttempts = tt.maxAttempts
+
+			start := time.Now()
+			_, err := gp.SendCtx(context.Background(), tt.method, "test", [][2]string{}, []byte{})
+			if calls.Load() != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls.Load(), tt.wantCalls)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("took %v, want no long backoff", elapsed)
+			}
+			apiErr := &APIError{}
+			if tt.wantStatus == 0 && err != nil {
+				t.Fatalf("err = %v, want nil", err)
+			} else if tt.wantStatus != 0 && (!errors.As(err, &apiErr) || apiErr.Status != tt.wantStatus) {
+				t.Fatalf("err = %v, want status %d", err, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestSendRetryCancelledDuringBackoff(t *testing.T) {
+	calls := atomic.Int32{}
+	gp := newTestPlayer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	gp.Retry.BaseDelay, gp.Retry.MaxDelay = time.Hour, time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	retries := 0
+	gp.Retry.OnRetry = func(attempt int, delay time.Duration, err error) {
+		retries++
+		cancel()
+	}
+
+	start := time.Now()
+	_, err := gp.SendCtx(ctx, lib.GET, "test", [][2]string{}, []byte{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want %v", err, context.Canceled)
+	}
+	if calls.Load() != 1 || retries != 1 {
+		t.Errorf("calls = %d, retries = %d, want 1, 1", calls.Load(), retries)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("took %v, want return on cancel", elapsed)
+	}
+}
+
+func TestDo(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		header  [2]string
+		body    string
+		want    string
+		wantErr *APIError // Expected fields of the returned `APIError`, nil for success.
+		wantIs  error
+	}{
+		{name: "ok", status: 200, body: `{"id":"a"}`, want: `{"id":"a"}`},
+		{name: "no content", status: 204, want: ``},
+		{name: "html body", status: 502, body: `<html><body>Bad Gateway</body></html>`, wantErr: &APIError{Status: 502, Message: "Bad Gateway"}, wantIs: lib.Errors.ServerError},
+		{name: "empty body", status: 429, header: [2]string{"Retry-After", "60"}, wantErr: &APIError{Status: 429, Message: "Too Many Requests", RetryAfter: time.Minute}, wantIs: lib.Errors.RateLimited},
+		{name: "json error", status: 404, body: `{"error":{"status":404,"message":"Device not found","reason":"NO_ACTIVE_DEVICE"}}`, wantErr: &APIError{Status: 404, Message: "Device not found", Reason: "NO_ACTIVE_DEVICE"}, wantIs: lib.Errors.NoActiveDevice},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gp := newTestPlayer(t, func(w http.ResponseWriter, r *http.Request) {
+				if tt.header[0] != "" {
+					w.Header().Set(tt.header[0], tt.header[1])
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			})
+			gp.Retry.MaxAttempts = 1
+
+			res, err := gp.SendCtx(context.Background(), lib.GET, "me/player", [][2]string{}, []byte{})
+			if tt.wantErr == nil {
+				if err != nil || res == nil || string(res) != tt.want {
+					t.Fatalf("got %q, %v, want %q", res, err, tt.want)
+				}
+				return
+			}
+			apiErr := &APIError{}
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("err = %v, want an APIError", err)
+			}
+			want := *tt.wantErr
+			want.Method, want.Path = lib.GET, "me/player"
+			if *apiErr != want {
+				t.Errorf("err = %+v, want %+v", *apiErr, want)
+			}
+			if !errors.Is(err, tt.wantIs) {
+				t.Errorf("err does not match %v", tt.wantIs)
+			}
+		})
+	}
+}
+
+func TestGetPlaybackStateNoContent(t *testing.T) {
+	gp := newTestPlayer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	if _, err := gp.Player.GetPlaybackState(); !errors.Is(err, lib.Errors.NoContent) {
+		t.Fatalf("err = %v, want %v", err, lib.Errors.NoContent)
+	}
+}
@@ -0,0 +1,80 @@
+package gotify
+
+import (
+	"context"
+	"strings"
+
+	"github.com/skip2/go-qrcode"
+	"golang.org/x/oauth2"
+)
+
+// Authenticate without a local browser, the user logs in on another device using the printed URL or QR code.
+//
+// A callback server listens on addr (Ex: ":5050" to be reachable on the LAN), the redirect URL should point to this device.
+// Pasting the redirect URL into stdin is always accepted as well, including when the callback server fails to listen.
+// The URL is passed to the auth prompt, when qr is true the URL and its QR code are passed to the QR prompt instead (see `WithAuthQRPrompt`).
+func (gp *GotifyPlayer) AuthenticateHeadless(addr string, qr bool) error {
+	return gp.AuthenticateHeadlessCtx(context.Background(), addr, qr)
+}
+
+// Authenticate without a local browser, the user logs in on another device using the printed URL or QR code.
+//
+// A callback server listens on addr (Ex: ":5050" to be reachable on the LAN), the redirect URL should point to this device.
+// Pasting the redirect URL into stdin is always accepted as well, including when the callback server fails to listen.
+// The URL is passed to the auth prompt, when qr is true the URL and its QR code are passed to the QR prompt instead (see `WithAuthQRPrompt`).
+func (gp *GotifyPlayer) AuthenticateHeadlessCtx(ctx context.Context, addr string, qr bool) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	state, callbacks := oauth2.GenerateVerifier(), make(chan authCallback)
+	stopStdin := readStdin(ctx, func(error) {}, callbacks)
+	stopServer, err := gp.serveCallbacks(ctx, func(error) {}, addr, state, callbacks)
+	if err != nil {
+		stopServer = func() {}
+	}
+	defer func() {
+		cancel(nil) // Release handlers waiting on callbacks before shutting down, as shutdown waits for them.
+		stopServer()
+		stopStdin()
+	}()
+
+	prompt := gp.authUserMsgCallback
+	if qr && gp.authQRMsgCallback != nil {
+		prompt = func(url string) {
+			if code, err := QRCode(url); err == nil {
+				gp.authQRMsgCallback(url, code)
+			} else {
+				gp.authUserMsgCallback(url)
+			}
+		}
+	}
+	return gp.authorize(ctx, state, prompt, callbacks)
+}
+
+// QRCode renders text as a QR code for terminals, using half blocks so every line holds two rows of modules.
+//
+// Light modules are drawn as blocks, as such the code is meant for terminals with a dark background.
+func QRCode(text string) (string, error) {
+	code, err := qrcode.New(text, qrcode.Low)
+	if err != nil {
+		return "", err
+	}
+	bitmap := code.Bitmap()
+	sb := strings.Builder{}
+	for y := 0; y < len(bitmap); y += 2 {
+		for x := range bitmap[y] {
+			top, bottom := !bitmap[y][x], y+1 < len(bitmap) && !bitmap[y+1][x]
+			switch {
+			case top && bottom:
+				sb.WriteString("█")
+			case top:
+				sb.WriteString("▀")
+			case bottom:
+				sb.WriteString("▄")
+			default:
+				sb.WriteString(" ")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String(), nil
+}
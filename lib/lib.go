@@ -1,7 +1,15 @@
 package lib
 
 import (
+	"encoding/json"
 	"errors"
+	"iter"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
 type (
@@ -43,29 +51,301 @@ const (
 	URIResourceUser      URIResource = "user"
 )
 
-var Errors = struct{ UnexpectedResponse error }{
-	UnexpectedResponse: errors.New("unexpected response"),
+var Errors = struct {
+	UnexpectedResponse   error
+	NoContent            error
+	NoToken              error
+	AuthenticationFailed error
+	InvalidState         error
+	InvalidGenre         error
+	BadRequest           error
+	Unauthorized         error
+	Forbidden            error
+	NotFound             error
+	RateLimited          error
+	ServerError          error
+	NoActiveDevice       error
+	PremiumRequired      error
+	MissingScope         error
+	InvalidRequest       error
+}{
+	UnexpectedResponse:   errors.New("unexpected response"),
+	NoContent:            errors.New("no content"),
+	NoToken:              errors.New("no token"),
+	AuthenticationFailed: errors.New("failed authentication"),
+	InvalidState:         errors.New("invalid state"),
+	InvalidGenre:         errors.New("invalid genre"),
+	BadRequest:           errors.New("bad request"),
+	Unauthorized:         errors.New("unauthorized"),
+	Forbidden:            errors.New("forbidden"),
+	NotFound:             errors.New("not found"),
+	RateLimited:          errors.New("rate limited"),
+	ServerError:          errors.New("server error"),
+	NoActiveDevice:       errors.New("no active device"),
+	PremiumRequired:      errors.New("premium required"),
+	MissingScope:         errors.New("missing scope"),
+	InvalidRequest:       errors.New("invalid request"),
+}
+
+// APIError is returned for error responses of the Spotify Web API, use `errors.As` to inspect it or `errors.Is` to match it against `Errors`.
+type APIError struct {
+	Status     int           // HTTP status code of the response.
+	Message    string        // Message as reported by Spotify.
+	Reason     string        // Reason as reported by Spotify, only set for player errors, https://developer.spotify.com/documentation/web-api/reference/#/player-error-reasons
+	Method     HTTPMethod    // Method of the failed request.
+	Path       string        // Path of the failed request, relative to the API base URL.
+	RetryAfter time.Duration // Value of the `Retry-After` header, zero if absent.
+}
+
+func (e *APIError) Error() string {
+	msg := strconv.Itoa(e.Status) + " " + string(e.Method) + " " + e.Path
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.Reason != "" {
+		msg += " (" + e.Reason + ")"
+	}
+	return msg
+}
+
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case Errors.BadRequest:
+		return e.Status == http.StatusBadRequest
+	case Errors.Unauthorized:
+		return e.Status == http.StatusUnauthorized
+	case Errors.Forbidden:
+		return e.Status == http.StatusForbidden
+	case Errors.NotFound:
+		return e.Status == http.StatusNotFound
+	case Errors.RateLimited:
+		return e.Status == http.StatusTooManyRequests
+	case Errors.ServerError:
+		return e.Status >= http.StatusInternalServerError
+	case Errors.NoActiveDevice:
+		return e.Reason == "NO_ACTIVE_DEVICE"
+	case Errors.PremiumRequired:
+		return e.Reason == "PREMIUM_REQUIRED"
+	}
+	return false
 }
 
 func NewURI(resource URIResource, id string) URI {
 	return URI("spotify:" + string(resource) + ":" + id)
 }
 
+// Resource returns the resource of the URI, Ex: `URIResourceTrack` for "spotify:track:4iV5W9uYEdYUVa79Axb7Rh", empty if the URI is malformed.
+func (uri URI) Resource() URIResource {
+	parts := strings.Split(string(uri), ":")
+	if len(parts) < 3 || parts[0] != "spotify" {
+		return ""
+	}
+	return URIResource(parts[len(parts)-2])
+}
+
+// ID returns the ID of the URI, Ex: "4iV5W9uYEdYUVa79Axb7Rh" for "spotify:track:4iV5W9uYEdYUVa79Axb7Rh", empty if the URI is malformed.
+func (uri URI) ID() string {
+	parts := strings.Split(string(uri), ":")
+	if len(parts) < 3 || parts[0] != "spotify" {
+		return ""
+	}
+	return parts[len(parts)-1]
+}
+
+func (item *PlayableItem) UnmarshalJSON(data []byte) error {
+	head := struct {
+		Type string `json:"type"`
+	}{}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return err
+	}
+	*item = PlayableItem{Type: head.Type}
+	switch head.Type {
+	case "track":
+		item.track = &TrackObject{}
+		return json.Unmarshal(data, item.track)
+	case "episode":
+		item.episode = &EpisodeObject{}
+		return json.Unmarshal(data, item.episode)
+	}
+	return nil
+}
+
+func (item PlayableItem) MarshalJSON() ([]byte, error) {
+	if item.track != nil {
+		return json.Marshal(item.track)
+	} else if item.episode != nil {
+		return json.Marshal(item.episode)
+	}
+	return []byte("null"), nil
+}
+
+// NewPlayableTrack wraps a track as `PlayableItem`.
+func NewPlayableTrack(track TrackObject) PlayableItem {
+	return PlayableItem{Type: "track", track: &track}
+}
+
+// NewPlayableEpisode wraps an episode as `PlayableItem`.
+func NewPlayableEpisode(episode EpisodeObject) PlayableItem {
+	return PlayableItem{Type: "episode", episode: &episode}
+}
+
+// AsTrack returns the item as track, ok is false if the item is not a track.
+func (item PlayableItem) AsTrack() (track TrackObject, ok bool) {
+	if item.track == nil {
+		return TrackObject{}, false
+	}
+	return *item.track, true
+}
+
+// AsEpisode returns the item as episode, ok is false if the item is not an episode.
+func (item PlayableItem) AsEpisode() (episode EpisodeObject, ok bool) {
+	if item.episode == nil {
+		return EpisodeObject{}, false
+	}
+	return *item.episode, true
+}
+
+// ID returns the ID of the track or episode, empty if there is no item.
+func (item PlayableItem) ID() string {
+	if item.track != nil {
+		return item.track.ID
+	} else if item.episode != nil {
+		return item.episode.ID
+	}
+	return ""
+}
+
+// URI returns the URI of the track or episode, empty if there is no item.
+func (item PlayableItem) URI() URI {
+	if item.track != nil {
+		return URI(item.track.URI)
+	} else if item.episode != nil {
+		return URI(item.episode.URI)
+	}
+	return ""
+}
+
+// Name returns the name of the track or episode, empty if there is no item.
+func (item PlayableItem) Name() string {
+	if item.track != nil {
+		return item.track.Name
+	} else if item.episode != nil {
+		return item.episode.Name
+	}
+	return ""
+}
+
+// Duration returns the duration of the track or episode, zero if there is no item.
+func (item PlayableItem) Duration() time.Duration {
+	if item.track != nil {
+		return time.Duration(item.track.DurationMs) * time.Millisecond
+	} else if item.episode != nil {
+		return time.Duration(item.episode.DurationMs) * time.Millisecond
+	}
+	return 0
+}
+
+// Paginate iterates over all items of an offset paged endpoint, `fetch` is called with the offset of each page.
+//
+// Iteration stops after the last page, once `Total` is reached or after yielding the first error.
+func Paginate[T any](fetch func(offset int) ([]T, ItemsHeaders, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for offset := 0; ; {
+			items, headers, err := fetch(offset)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			offset += len(items)
+			if len(items) == 0 || headers.Next == "" || offset >= headers.Total {
+				return
+			}
+		}
+	}
+}
+
+// Batch splits items into batches of at most size and calls fn for each batch, running up to concurrency batches at once.
+//
+// Results are merged in input order, the first error in input order is returned.
+func Batch[T, R any](items []T, size, concurrency int, fn func(batch []T) ([]R, error)) ([]R, error) {
+	batches := slices.Collect(slices.Chunk(items, max(1, size)))
+	results, errs := make([][]R, len(batches)), make([]error, len(batches))
+	if concurrency <= 1 {
+		for i, batch := range batches {
+			if results[i], errs[i] = fn(batch); errs[i] != nil {
+				return []R{}, errs[i]
+			}
+		}
+		return slices.Concat(results...), nil
+	}
+
+	sem, wg := make(chan struct{}, concurrency), sync.WaitGroup{}
+	for i, batch := range batches {
+		sem <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-sem }()
+			results[i], errs[i] = fn(batch)
+		})
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return []R{}, err
+		}
+	}
+	return slices.Concat(results...), nil
+}
+
+// BatchDo is `Batch` for calls without results.
+func BatchDo[T any](items []T, size, concurrency int, fn func(batch []T) error) error {
+	_, err := Batch(items, size, concurrency, func(batch []T) ([]struct{}, error) { return nil, fn(batch) })
+	return err
+}
+
+// PaginateCursor iterates over all items of a cursor paged endpoint, `fetch` is called with the cursor of each page and returns the cursor of the next page.
+//
+// Iteration stops once no next cursor is returned or after yielding the first error.
+func PaginateCursor[T any](fetch func(cursor string) ([]T, string, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for cursor := ""; ; {
+			items, next, err := fetch(cursor)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if len(items) == 0 || next == "" || next == cursor {
+				return
+			}
+			cursor = next
+		}
+	}
+}
+
 type (
-	Context struct {
-		Context struct {
-			Type string `json:"type"`
-			Href string `json:"href"`
-			externalUrls
-			URI string `json:"uri"`
-		} `json:"context"`
+	// Paging is a page of an offset paged endpoint.
+	Paging[T any] struct {
+		ItemsHeaders
+		Items []T `json:"items"`
 	}
-	Cursors struct {
-		Cursors struct {
-			After  string `json:"after"`
-			Before string `json:"before"`
-		} `json:"cursors"`
+	// CursorPaging is a page of a cursor paged endpoint.
+	CursorPaging[T any] struct {
+		ItemsCursorsHeaders
+		Items []T `json:"items"`
 	}
+
 	ItemsHeaders struct {
 		Href     string `json:"href"`
 		Limit    int    `json:"limit"`
@@ -75,38 +355,53 @@ type (
 		Total    int    `json:"total"`
 	}
 	ItemsCursorsHeaders struct {
-		Href  string `json:"href"`
-		Limit int    `json:"limit"`
-		Next  string `json:"next"`
-		Cursors
-		Total int `json:"total"`
+		Href    string  `json:"href"`
+		Limit   int     `json:"limit"`
+		Next    string  `json:"next"`
+		Cursors Cursors `json:"cursors"`
+		Total   int     `json:"total"`
+	}
+	Cursors struct {
+		After  string `json:"after"`
+		Before string `json:"before"`
+	}
+
+	ContextObject struct {
+		Type         string       `json:"type"`
+		Href         string       `json:"href"`
+		ExternalUrls ExternalURLs `json:"external_urls"`
+		URI          string       `json:"uri"`
+	}
+	Context struct {
+		Context ContextObject `json:"context"`
 	}
 	Profile struct {
-		Country         string `json:"country"`
-		DisplayName     string `json:"display_name"`
-		Email           string `json:"email"`
-		ExplicitContent struct {
-			FilterEnabled bool `json:"filter_enabled"`
-			FilterLocked  bool `json:"filter_locked"`
-		} `json:"explicit_content"`
-		externalUrls
-		followers
-		Href string `json:"href"`
-		ID   string `json:"id"`
-		images
-		Product string `json:"product"`
-		Type    string `json:"type"`
-		URI     string `json:"uri"`
+		Country         string          `json:"country"`
+		DisplayName     string          `json:"display_name"`
+		Email           string          `json:"email"`
+		ExplicitContent ExplicitContent `json:"explicit_content"`
+		ExternalUrls    ExternalURLs    `json:"external_urls"`
+		Followers       Followers       `json:"followers"`
+		Href            string          `json:"href"`
+		ID              string          `json:"id"`
+		Images          []Image         `json:"images"`
+		Product         string          `json:"product"`
+		Type            string          `json:"type"`
+		URI             string          `json:"uri"`
 	}
 	ProfilePublic struct {
-		DisplayName string `json:"display_name"`
-		externalUrls
-		followers
-		Href string `json:"href"`
-		ID   string `json:"id"`
-		images
-		Type string `json:"type"`
-		URI  string `json:"uri"`
+		DisplayName  string       `json:"display_name"`
+		ExternalUrls ExternalURLs `json:"external_urls"`
+		Followers    Followers    `json:"followers"`
+		Href         string       `json:"href"`
+		ID           string       `json:"id"`
+		Images       []Image      `json:"images"`
+		Type         string       `json:"type"`
+		URI          string       `json:"uri"`
+	}
+	ExplicitContent struct {
+		FilterEnabled bool `json:"filter_enabled"`
+		FilterLocked  bool `json:"filter_locked"`
 	}
 	Actions struct {
 		InterruptingPlayback  bool `json:"interrupting_playback"`
@@ -131,427 +426,504 @@ type (
 		SupportsVolume   bool   `json:"supports_volume"`
 	}
 	Categorie struct {
+		Href  string  `json:"href"`
+		Icons []Image `json:"icons"`
+		ID    string  `json:"id"`
+		Name  string  `json:"name"`
+	}
+
+	Image struct {
+		URL    string `json:"url"`
+		Height int    `json:"height"`
+		Width  int    `json:"width"`
+	}
+	UserSimpleObject struct {
+		ExternalUrls ExternalURLs `json:"external_urls"`
+		Href         string       `json:"href"`
+		ID           string       `json:"id"`
+		Type         string       `json:"type"`
+		URI          string       `json:"uri"`
+		DisplayName  string       `json:"display_name"`
+	}
+	Restrictions struct {
+		Reason string `json:"reason"`
+	}
+	Followers struct {
+		Href  string `json:"href"`
+		Total int    `json:"total"`
+	}
+	ExternalURLs struct {
+		Spotify string `json:"spotify"`
+	}
+	Copyright struct {
+		Text string `json:"text"`
+		Type string `json:"type"`
+	}
+	ExternalIDs struct {
+		Isrc string `json:"isrc"`
+		Ean  string `json:"ean"`
+		Upc  string `json:"upc"`
+	}
+	ResumePoint struct {
+		FullyPlayed      bool `json:"fully_played"`
+		ResumePositionMs int  `json:"resume_position_ms"`
+	}
+	LinkedFrom struct {
+		ExternalUrls ExternalURLs `json:"external_urls"`
+		Href         string       `json:"href"`
+		ID           string       `json:"id"`
+		Type         string       `json:"type"`
+		URI          string       `json:"uri"`
+	}
+	TracksReference struct {
 		Href  string `json:"href"`
-		Icons []struct {
-			URL    string `json:"url"`
-			Height int    `json:"height"`
-			Width  int    `json:"width"`
-		} `json:"icons"`
-		ID   string `json:"id"`
+		Total int    `json:"total"`
+	}
+	Author struct {
 		Name string `json:"name"`
 	}
-
-	images struct {
-		Images []struct {
-			URL    string `json:"url"`
-			Height int    `json:"height"`
-			Width  int    `json:"width"`
-		} `json:"images"`
-	}
-	owner struct {
-		Owner struct {
-			ExternalUrls struct {
-				Spotify string `json:"spotify"`
-			} `json:"external_urls"`
-			Href        string `json:"href"`
-			ID          string `json:"id"`
-			Type        string `json:"type"`
-			URI         string `json:"uri"`
-			DisplayName string `json:"display_name"`
-		} `json:"owner"`
-	}
-	restrictions struct {
-		Restrictions struct {
-			Reason string `json:"reason"`
-		} `json:"restrictions"`
-	}
-	followers struct {
-		Followers struct {
-			Href  string `json:"href"`
-			Total int    `json:"total"`
-		} `json:"followers"`
-	}
-	externalUrls struct {
-		ExternalUrls struct {
-			Spotify string `json:"spotify"`
-		} `json:"external_urls"`
-	}
-	copyrights []struct {
-		Copyrights []struct {
-			Text string `json:"text"`
-			Type string `json:"type"`
-		} `json:"copyrights"`
-	}
-	externalIds struct {
-		ExternalIds struct {
-			Isrc string `json:"isrc"`
-			Ean  string `json:"ean"`
-			Upc  string `json:"upc"`
-		} `json:"external_ids"`
-	}
-	resumePoint struct {
-		ResumePoint struct {
-			FullyPlayed      bool `json:"fully_played"`
-			ResumePositionMs int  `json:"resume_position_ms"`
-		} `json:"resume_point"`
-	}
-	linkedFrom struct {
-		LinkedFrom struct {
-			externalUrls
-			Href string `json:"href"`
-			ID   string `json:"id"`
-			Type string `json:"type"`
-			URI  string `json:"uri"`
-		} `json:"linked_from"`
+	Narrator struct {
+		Name string `json:"name"`
 	}
 )
 
 type (
-	trackSimple struct {
+	TrackSimpleObject struct {
 		ArtistsSimple
-		AvailableMarkets []string `json:"available_markets"`
-		DiscNumber       int      `json:"disc_number"`
-		DurationMs       int      `json:"duration_ms"`
-		Explicit         bool     `json:"explicit"`
-		externalUrls
-		Href       string `json:"href"`
-		ID         string `json:"id"`
-		IsPlayable bool   `json:"is_playable"`
-		linkedFrom
-		restrictions
-		Name        string `json:"name"`
-		PreviewURL  string `json:"preview_url"`
-		TrackNumber int    `json:"track_number"`
-		Type        string `json:"type"`
-		URI         string `json:"uri"`
-		IsLocal     bool   `json:"is_local"`
-	}
-	TrackSimpleObject trackSimple
-
-	track struct {
+		AvailableMarkets []string     `json:"available_markets"`
+		DiscNumber       int          `json:"disc_number"`
+		DurationMs       int          `json:"duration_ms"`
+		Explicit         bool         `json:"explicit"`
+		ExternalUrls     ExternalURLs `json:"external_urls"`
+		Href             string       `json:"href"`
+		ID               string       `json:"id"`
+		IsPlayable       bool         `json:"is_playable"`
+		LinkedFrom       LinkedFrom   `json:"linked_from"`
+		Restrictions     Restrictions `json:"restrictions"`
+		Name             string       `json:"name"`
+		PreviewURL       string       `json:"preview_url"`
+		TrackNumber      int          `json:"track_number"`
+		Type             string       `json:"type"`
+		URI              string       `json:"uri"`
+		IsLocal          bool         `json:"is_local"`
+	}
+
+	TrackObject struct {
 		AlbumSimple
 		ArtistsSimple
-		AvailableMarkets []string `json:"available_markets"`
-		DiscNumber       int      `json:"disc_number"`
-		DurationMs       int      `json:"duration_ms"`
-		Explicit         bool     `json:"explicit"`
-		externalIds
-		externalUrls
-		Href       string `json:"href"`
-		ID         string `json:"id"`
-		IsPlayable bool   `json:"is_playable"`
-		linkedFrom
-		restrictions
-		Name        string `json:"name"`
-		Popularity  int    `json:"popularity"`
-		PreviewURL  string `json:"preview_url"`
-		TrackNumber int    `json:"track_number"`
-		Type        string `json:"type"`
-		URI         string `json:"uri"`
-		IsLocal     bool   `json:"is_local"`
+		AvailableMarkets []string     `json:"available_markets"`
+		DiscNumber       int          `json:"disc_number"`
+		DurationMs       int          `json:"duration_ms"`
+		Explicit         bool         `json:"explicit"`
+		ExternalIds      ExternalIDs  `json:"external_ids"`
+		ExternalUrls     ExternalURLs `json:"external_urls"`
+		Href             string       `json:"href"`
+		ID               string       `json:"id"`
+		IsPlayable       bool         `json:"is_playable"`
+		LinkedFrom       LinkedFrom   `json:"linked_from"`
+		Restrictions     Restrictions `json:"restrictions"`
+		Name             string       `json:"name"`
+		Popularity       int          `json:"popularity"`
+		PreviewURL       string       `json:"preview_url"`
+		TrackNumber      int          `json:"track_number"`
+		Type             string       `json:"type"`
+		URI              string       `json:"uri"`
+		IsLocal          bool         `json:"is_local"`
 	}
 	Track struct {
-		Track track `json:"track"`
+		Track TrackObject `json:"track"`
 	}
 	Tracks struct {
-		Tracks []track `json:"tracks"`
+		Tracks []TrackObject `json:"tracks"`
+	}
+	SavedTrack struct {
+		AddedAt string      `json:"added_at"`
+		Track   TrackObject `json:"track"`
+	}
+	PlayedTrack struct {
+		Track    TrackObject   `json:"track"`
+		PlayedAt string        `json:"played_at"`
+		Context  ContextObject `json:"context"`
 	}
-	TrackObject track
 
-	artistSimple struct {
-		externalUrls
-		Href string `json:"href"`
-		ID   string `json:"id"`
-		Name string `json:"name"`
-		Type string `json:"type"`
-		URI  string `json:"uri"`
+	ArtistSimpleObject struct {
+		ExternalUrls ExternalURLs `json:"external_urls"`
+		Href         string       `json:"href"`
+		ID           string       `json:"id"`
+		Name         string       `json:"name"`
+		Type         string       `json:"type"`
+		URI          string       `json:"uri"`
 	}
 	ArtistsSimple struct {
-		Artists []artistSimple `json:"artists"`
+		Artists []ArtistSimpleObject `json:"artists"`
 	}
 
-	artist struct {
-		externalUrls
-		followers
-		Genres []string `json:"genres"`
-		Href   string   `json:"href"`
-		ID     string   `json:"id"`
-		images
-		Name       string `json:"name"`
-		Popularity int    `json:"popularity"`
-		Type       string `json:"type"`
-		URI        string `json:"uri"`
+	ArtistObject struct {
+		ExternalUrls ExternalURLs `json:"external_urls"`
+		Followers    Followers    `json:"followers"`
+		Genres       []string     `json:"genres"`
+		Href         string       `json:"href"`
+		ID           string       `json:"id"`
+		Images       []Image      `json:"images"`
+		Name         string       `json:"name"`
+		Popularity   int          `json:"popularity"`
+		Type         string       `json:"type"`
+		URI          string       `json:"uri"`
 	}
 	Artists struct {
-		Artists []artist `json:"artists"`
-	}
-	ArtistObject artist
-
-	albumSimple struct {
-		AlbumType        string   `json:"album_type"`
-		TotalTracks      int      `json:"total_tracks"`
-		AvailableMarkets []string `json:"available_markets"`
-		externalUrls
-		Href string `json:"href"`
-		ID   string `json:"id"`
-		images
-		Name                 string `json:"name"`
-		ReleaseDate          string `json:"release_date"`
-		ReleaseDatePrecision string `json:"release_date_precision"`
-		restrictions
-		Type string `json:"type"`
-		URI  string `json:"uri"`
+		Artists []ArtistObject `json:"artists"`
+	}
+	FollowedArtists struct {
+		Artists CursorPaging[ArtistObject] `json:"artists"`
+	}
+
+	AlbumSimpleObject struct {
+		AlbumType            string       `json:"album_type"`
+		TotalTracks          int          `json:"total_tracks"`
+		AvailableMarkets     []string     `json:"available_markets"`
+		ExternalUrls         ExternalURLs `json:"external_urls"`
+		Href                 string       `json:"href"`
+		ID                   string       `json:"id"`
+		Images               []Image      `json:"images"`
+		Name                 string       `json:"name"`
+		ReleaseDate          string       `json:"release_date"`
+		ReleaseDatePrecision string       `json:"release_date_precision"`
+		Restrictions         Restrictions `json:"restrictions"`
+		Type                 string       `json:"type"`
+		URI                  string       `json:"uri"`
 		ArtistsSimple
 		AlbumGroup string `json:"album_group"`
 	}
 	AlbumSimple struct {
-		Album albumSimple `json:"album"`
-	}
-	AlbumSimpleObject albumSimple
-
-	album struct {
-		AlbumType        string   `json:"album_type"`
-		TotalTracks      int      `json:"total_tracks"`
-		AvailableMarkets []string `json:"available_markets"`
-		externalUrls
-		Href string `json:"href"`
-		ID   string `json:"id"`
-		images
-		Name                 string `json:"name"`
-		ReleaseDate          string `json:"release_date"`
-		ReleaseDatePrecision string `json:"release_date_precision"`
-		restrictions
-		Type string `json:"type"`
-		URI  string `json:"uri"`
+		Album AlbumSimpleObject `json:"album"`
+	}
+
+	AlbumObject struct {
+		AlbumType            string       `json:"album_type"`
+		TotalTracks          int          `json:"total_tracks"`
+		AvailableMarkets     []string     `json:"available_markets"`
+		ExternalUrls         ExternalURLs `json:"external_urls"`
+		Href                 string       `json:"href"`
+		ID                   string       `json:"id"`
+		Images               []Image      `json:"images"`
+		Name                 string       `json:"name"`
+		ReleaseDate          string       `json:"release_date"`
+		ReleaseDatePrecision string       `json:"release_date_precision"`
+		Restrictions         Restrictions `json:"restrictions"`
+		Type                 string       `json:"type"`
+		URI                  string       `json:"uri"`
 		ArtistsSimple
-		Tracks struct {
-			ItemsHeaders
-			Items []trackSimple `json:"items"`
-		} `json:"tracks"`
-		copyrights
-		externalIds
-		Genres     []string `json:"genres"`
-		Label      string   `json:"label"`
-		Popularity int      `json:"popularity"`
+		Tracks      Paging[TrackSimpleObject] `json:"tracks"`
+		Copyrights  []Copyright               `json:"copyrights"`
+		ExternalIds ExternalIDs               `json:"external_ids"`
+		Genres      []string                  `json:"genres"`
+		Label       string                    `json:"label"`
+		Popularity  int                       `json:"popularity"`
 	}
 	Album struct {
-		Album album `json:"album"`
+		Album AlbumObject `json:"album"`
 	}
 	Albums struct {
-		Albums []album `json:"albums"`
-	}
-
-	playlistSimple struct {
-		Collaborative bool   `json:"collaborative"`
-		Description   string `json:"description"`
-		externalUrls
-		Href string `json:"href"`
-		ID   string `json:"id"`
-		images
-		owner
-		Public     bool   `json:"public"`
-		SnapshotID string `json:"snapshot_id"`
-		Tracks     struct {
-			Href  string `json:"href"`
-			Total int    `json:"total"`
-		} `json:"tracks"`
-		Type string `json:"type"`
-		URI  string `json:"uri"`
-	}
-	PlaylistSimpleObject playlistSimple
-
-	showSimple struct {
-		AvailableMarkets []string `json:"available_markets"`
-		copyrights
-		Description     string `json:"description"`
-		HTMLDescription string `json:"html_description"`
-		Explicit        bool   `json:"explicit"`
-		externalUrls
-		Href string `json:"href"`
-		ID   string `json:"id"`
-		images
-		IsExternallyHosted bool     `json:"is_externally_hosted"`
-		Languages          []string `json:"languages"`
-		MediaType          string   `json:"media_type"`
-		Name               string   `json:"name"`
-		Publisher          string   `json:"publisher"`
-		Type               string   `json:"type"`
-		URI                string   `json:"uri"`
-		TotalEpisodes      int      `json:"total_episodes"`
+		Albums []AlbumObject `json:"albums"`
+	}
+	SavedAlbum struct {
+		AddedAt string      `json:"added_at"`
+		Album   AlbumObject `json:"album"`
+	}
+	NewReleases struct {
+		Albums Paging[AlbumSimpleObject] `json:"albums"`
+	}
+
+	PlaylistSimpleObject struct {
+		Collaborative bool             `json:"collaborative"`
+		Description   string           `json:"description"`
+		ExternalUrls  ExternalURLs     `json:"external_urls"`
+		Href          string           `json:"href"`
+		ID            string           `json:"id"`
+		Images        []Image          `json:"images"`
+		Owner         UserSimpleObject `json:"owner"`
+		Public        bool             `json:"public"`
+		SnapshotID    string           `json:"snapshot_id"`
+		Tracks        TracksReference  `json:"tracks"`
+		Type          string           `json:"type"`
+		URI           string           `json:"uri"`
+	}
+
+	PlaylistObject struct {
+		Collaborative bool                        `json:"collaborative"`
+		Description   string                      `json:"description"`
+		ExternalUrls  ExternalURLs                `json:"external_urls"`
+		Followers     Followers                   `json:"followers"`
+		Href          string                      `json:"href"`
+		ID            string                      `json:"id"`
+		Images        []Image                     `json:"images"`
+		Owner         UserSimpleObject            `json:"owner"`
+		Public        bool                        `json:"public"`
+		SnapshotID    string                      `json:"snapshot_id"`
+		Tracks        Paging[PlaylistTrackObject] `json:"tracks"`
+		Type          string                      `json:"type"`
+		URI           string                      `json:"uri"`
+	}
+
+	PlaylistTrackObject struct {
+		AddedAt string           `json:"added_at"`
+		AddedBy UserSimpleObject `json:"added_by"`
+		IsLocal bool             `json:"is_local"`
+		Track   PlayableItem     `json:"track"`
+	}
+
+	ShowSimpleObject struct {
+		AvailableMarkets   []string     `json:"available_markets"`
+		Copyrights         []Copyright  `json:"copyrights"`
+		Description        string       `json:"description"`
+		HTMLDescription    string       `json:"html_description"`
+		Explicit           bool         `json:"explicit"`
+		ExternalUrls       ExternalURLs `json:"external_urls"`
+		Href               string       `json:"href"`
+		ID                 string       `json:"id"`
+		Images             []Image      `json:"images"`
+		IsExternallyHosted bool         `json:"is_externally_hosted"`
+		Languages          []string     `json:"languages"`
+		MediaType          string       `json:"media_type"`
+		Name               string       `json:"name"`
+		Publisher          string       `json:"publisher"`
+		Type               string       `json:"type"`
+		URI                string       `json:"uri"`
+		TotalEpisodes      int          `json:"total_episodes"`
 	}
 	ShowSimple struct {
-		Show showSimple `json:"show"`
-	}
-	ShowSimpleObject showSimple
-
-	episodeSimple struct {
-		AudioPreviewURL string `json:"audio_preview_url"`
-		Description     string `json:"description"`
-		HTMLDescription string `json:"html_description"`
-		DurationMs      int    `json:"duration_ms"`
-		Explicit        bool   `json:"explicit"`
-		externalUrls
-		Href string `json:"href"`
-		ID   string `json:"id"`
-		images
-		IsExternallyHosted   bool     `json:"is_externally_hosted"`
-		IsPlayable           bool     `json:"is_playable"`
-		Language             string   `json:"language"`
-		Languages            []string `json:"languages"`
-		Name                 string   `json:"name"`
-		ReleaseDate          string   `json:"release_date"`
-		ReleaseDatePrecision string   `json:"release_date_precision"`
-		resumePoint
-		Type string `json:"type"`
-		URI  string `json:"uri"`
-		restrictions
-	}
-	EpisodeSimpleObject episodeSimple
-
-	episode struct {
-		AudioPreviewURL string `json:"audio_preview_url"`
-		Description     string `json:"description"`
-		HTMLDescription string `json:"html_description"`
-		DurationMs      int    `json:"duration_ms"`
-		Explicit        bool   `json:"explicit"`
-		externalUrls
-		Href string `json:"href"`
-		ID   string `json:"id"`
-		images
-		IsExternallyHosted   bool     `json:"is_externally_hosted"`
-		IsPlayable           bool     `json:"is_playable"`
-		Language             string   `json:"language"`
-		Languages            []string `json:"languages"`
-		Name                 string   `json:"name"`
-		ReleaseDate          string   `json:"release_date"`
-		ReleaseDatePrecision string   `json:"release_date_precision"`
-		resumePoint
-		Type string `json:"type"`
-		URI  string `json:"uri"`
-		restrictions
+		Show ShowSimpleObject `json:"show"`
+	}
+
+	ShowObject struct {
+		AvailableMarkets   []string                    `json:"available_markets"`
+		Copyrights         []Copyright                 `json:"copyrights"`
+		Description        string                      `json:"description"`
+		HTMLDescription    string                      `json:"html_description"`
+		Explicit           bool                        `json:"explicit"`
+		ExternalUrls       ExternalURLs                `json:"external_urls"`
+		Href               string                      `json:"href"`
+		ID                 string                      `json:"id"`
+		Images             []Image                     `json:"images"`
+		IsExternallyHosted bool                        `json:"is_externally_hosted"`
+		Languages          []string                    `json:"languages"`
+		MediaType          string                      `json:"media_type"`
+		Name               string                      `json:"name"`
+		Publisher          string                      `json:"publisher"`
+		Type               string                      `json:"type"`
+		URI                string                      `json:"uri"`
+		TotalEpisodes      int                         `json:"total_episodes"`
+		Episodes           Paging[EpisodeSimpleObject] `json:"episodes"`
+	}
+	Shows struct {
+		Shows []ShowSimpleObject `json:"shows"`
+	}
+	SavedShow struct {
+		AddedAt string           `json:"added_at"`
+		Show    ShowSimpleObject `json:"show"`
+	}
+
+	EpisodeSimpleObject struct {
+		AudioPreviewURL      string       `json:"audio_preview_url"`
+		Description          string       `json:"description"`
+		HTMLDescription      string       `json:"html_description"`
+		DurationMs           int          `json:"duration_ms"`
+		Explicit             bool         `json:"explicit"`
+		ExternalUrls         ExternalURLs `json:"external_urls"`
+		Href                 string       `json:"href"`
+		ID                   string       `json:"id"`
+		Images               []Image      `json:"images"`
+		IsExternallyHosted   bool         `json:"is_externally_hosted"`
+		IsPlayable           bool         `json:"is_playable"`
+		Language             string       `json:"language"`
+		Languages            []string     `json:"languages"`
+		Name                 string       `json:"name"`
+		ReleaseDate          string       `json:"release_date"`
+		ReleaseDatePrecision string       `json:"release_date_precision"`
+		ResumePoint          ResumePoint  `json:"resume_point"`
+		Type                 string       `json:"type"`
+		URI                  string       `json:"uri"`
+		Restrictions         Restrictions `json:"restrictions"`
+	}
+
+	EpisodeObject struct {
+		AudioPreviewURL      string       `json:"audio_preview_url"`
+		Description          string       `json:"description"`
+		HTMLDescription      string       `json:"html_description"`
+		DurationMs           int          `json:"duration_ms"`
+		Explicit             bool         `json:"explicit"`
+		ExternalUrls         ExternalURLs `json:"external_urls"`
+		Href                 string       `json:"href"`
+		ID                   string       `json:"id"`
+		Images               []Image      `json:"images"`
+		IsExternallyHosted   bool         `json:"is_externally_hosted"`
+		IsPlayable           bool         `json:"is_playable"`
+		Language             string       `json:"language"`
+		Languages            []string     `json:"languages"`
+		Name                 string       `json:"name"`
+		ReleaseDate          string       `json:"release_date"`
+		ReleaseDatePrecision string       `json:"release_date_precision"`
+		ResumePoint          ResumePoint  `json:"resume_point"`
+		Type                 string       `json:"type"`
+		URI                  string       `json:"uri"`
+		Restrictions         Restrictions `json:"restrictions"`
 		ShowSimple
 	}
 	Episode struct {
-		Episode episode `json:"episode"`
+		Episode EpisodeObject `json:"episode"`
 	}
 	Episodes struct {
-		Episodes []episode `json:"episodes"`
-	}
-	EpisodeObject episode
-
-	audiobookSimple struct {
-		Authors []struct {
-			Name string `json:"name"`
-		} `json:"authors"`
-		AvailableMarkets []string `json:"available_markets"`
-		copyrights
-		Description     string `json:"description"`
-		HTMLDescription string `json:"html_description"`
-		Edition         string `json:"edition"`
-		Explicit        bool   `json:"explicit"`
-		externalUrls
-		Href string `json:"href"`
-		ID   string `json:"id"`
-		images
-		Languages []string `json:"languages"`
-		MediaType string   `json:"media_type"`
-		Name      string   `json:"name"`
-		Narrators []struct {
-			Name string `json:"name"`
-		} `json:"narrators"`
-		Publisher     string `json:"publisher"`
-		Type          string `json:"type"`
-		URI           string `json:"uri"`
-		TotalChapters int    `json:"total_chapters"`
+		Episodes []EpisodeObject `json:"episodes"`
+	}
+	SavedEpisode struct {
+		AddedAt string        `json:"added_at"`
+		Episode EpisodeObject `json:"episode"`
+	}
+
+	// PlayableItem is either a track or an episode, decoded based on its `type`, use `AsTrack` or `AsEpisode` to access it.
+	PlayableItem struct {
+		Type    string // Either "track" or "episode", empty if there is no item.
+		track   *TrackObject
+		episode *EpisodeObject
+	}
+
+	AudiobookSimpleObject struct {
+		Authors          []Author     `json:"authors"`
+		AvailableMarkets []string     `json:"available_markets"`
+		Copyrights       []Copyright  `json:"copyrights"`
+		Description      string       `json:"description"`
+		HTMLDescription  string       `json:"html_description"`
+		Edition          string       `json:"edition"`
+		Explicit         bool         `json:"explicit"`
+		ExternalUrls     ExternalURLs `json:"external_urls"`
+		Href             string       `json:"href"`
+		ID               string       `json:"id"`
+		Images           []Image      `json:"images"`
+		Languages        []string     `json:"languages"`
+		MediaType        string       `json:"media_type"`
+		Name             string       `json:"name"`
+		Narrators        []Narrator   `json:"narrators"`
+		Publisher        string       `json:"publisher"`
+		Type             string       `json:"type"`
+		URI              string       `json:"uri"`
+		TotalChapters    int          `json:"total_chapters"`
 	}
 	AudiobookSimple struct {
-		Audiobook audiobookSimple `json:"audiobook"`
-	}
-	AudiobookSimpleObject audiobookSimple
-
-	audiobook struct {
-		Authors []struct {
-			Name string `json:"name"`
-		} `json:"authors"`
-		AvailableMarkets []string `json:"available_markets"`
-		copyrights
-		Description     string `json:"description"`
-		HTMLDescription string `json:"html_description"`
-		Edition         string `json:"edition"`
-		Explicit        bool   `json:"explicit"`
-		externalUrls
-		Href string `json:"href"`
-		ID   string `json:"id"`
-		images
-		Languages []string `json:"languages"`
-		MediaType string   `json:"media_type"`
-		Name      string   `json:"name"`
-		Narrators []struct {
-			Name string `json:"name"`
-		} `json:"narrators"`
-		Publisher     string `json:"publisher"`
-		Type          string `json:"type"`
-		URI           string `json:"uri"`
-		TotalChapters int    `json:"total_chapters"`
-		Chapters      struct {
-			ItemsHeaders
-			Items []chapterSimple `json:"items"`
-		} `json:"chapters"`
+		Audiobook AudiobookSimpleObject `json:"audiobook"`
+	}
+
+	AudiobookObject struct {
+		Authors          []Author                    `json:"authors"`
+		AvailableMarkets []string                    `json:"available_markets"`
+		Copyrights       []Copyright                 `json:"copyrights"`
+		Description      string                      `json:"description"`
+		HTMLDescription  string                      `json:"html_description"`
+		Edition          string                      `json:"edition"`
+		Explicit         bool                        `json:"explicit"`
+		ExternalUrls     ExternalURLs                `json:"external_urls"`
+		Href             string                      `json:"href"`
+		ID               string                      `json:"id"`
+		Images           []Image                     `json:"images"`
+		Languages        []string                    `json:"languages"`
+		MediaType        string                      `json:"media_type"`
+		Name             string                      `json:"name"`
+		Narrators        []Narrator                  `json:"narrators"`
+		Publisher        string                      `json:"publisher"`
+		Type             string                      `json:"type"`
+		URI              string                      `json:"uri"`
+		TotalChapters    int                         `json:"total_chapters"`
+		Chapters         Paging[ChapterSimpleObject] `json:"chapters"`
 	}
 	Audiobooks struct {
-		Audiobooks []audiobook `json:"audiobooks"`
-	}
-	AudiobookObject audiobook
-
-	chapterSimple struct {
-		AudioPreviewURL  string   `json:"audio_preview_url"`
-		AvailableMarkets []string `json:"available_markets"`
-		ChapterNumber    int      `json:"chapter_number"`
-		Description      string   `json:"description"`
-		HTMLDescription  string   `json:"html_description"`
-		DurationMs       int      `json:"duration_ms"`
-		Explicit         bool     `json:"explicit"`
-		externalUrls
-		Href string `json:"href"`
-		ID   string `json:"id"`
-		images
-		IsPlayable           bool     `json:"is_playable"`
-		Languages            []string `json:"languages"`
-		Name                 string   `json:"name"`
-		ReleaseDate          string   `json:"release_date"`
-		ReleaseDatePrecision string   `json:"release_date_precision"`
-		resumePoint
-		Type string `json:"type"`
-		URI  string `json:"uri"`
-		restrictions
-	}
-	ChapterSimpleObject chapterSimple
-
-	chapter struct {
-		AudioPreviewURL  string   `json:"audio_preview_url"`
-		AvailableMarkets []string `json:"available_markets"`
-		ChapterNumber    int      `json:"chapter_number"`
-		Description      string   `json:"description"`
-		HTMLDescription  string   `json:"html_description"`
-		DurationMs       int      `json:"duration_ms"`
-		Explicit         bool     `json:"explicit"`
-		externalUrls
-		Href string `json:"href"`
-		ID   string `json:"id"`
-		images
-		IsPlayable           bool     `json:"is_playable"`
-		Languages            []string `json:"languages"`
-		Name                 string   `json:"name"`
-		ReleaseDate          string   `json:"release_date"`
-		ReleaseDatePrecision string   `json:"release_date_precision"`
-		resumePoint
-		Type string `json:"type"`
-		URI  string `json:"uri"`
-		restrictions
+		Audiobooks []AudiobookObject `json:"audiobooks"`
+	}
+
+	ChapterSimpleObject struct {
+		AudioPreviewURL      string       `json:"audio_preview_url"`
+		AvailableMarkets     []string     `json:"available_markets"`
+		ChapterNumber        int          `json:"chapter_number"`
+		Description          string       `json:"description"`
+		HTMLDescription      string       `json:"html_description"`
+		DurationMs           int          `json:"duration_ms"`
+		Explicit             bool         `json:"explicit"`
+		ExternalUrls         ExternalURLs `json:"external_urls"`
+		Href                 string       `json:"href"`
+		ID                   string       `json:"id"`
+		Images               []Image      `json:"images"`
+		IsPlayable           bool         `json:"is_playable"`
+		Languages            []string     `json:"languages"`
+		Name                 string       `json:"name"`
+		ReleaseDate          string       `json:"release_date"`
+		ReleaseDatePrecision string       `json:"release_date_precision"`
+		ResumePoint          ResumePoint  `json:"resume_point"`
+		Type                 string       `json:"type"`
+		URI                  string       `json:"uri"`
+		Restrictions         Restrictions `json:"restrictions"`
+	}
+
+	ChapterObject struct {
+		AudioPreviewURL      string       `json:"audio_preview_url"`
+		AvailableMarkets     []string     `json:"available_markets"`
+		ChapterNumber        int          `json:"chapter_number"`
+		Description          string       `json:"description"`
+		HTMLDescription      string       `json:"html_description"`
+		DurationMs           int          `json:"duration_ms"`
+		Explicit             bool         `json:"explicit"`
+		ExternalUrls         ExternalURLs `json:"external_urls"`
+		Href                 string       `json:"href"`
+		ID                   string       `json:"id"`
+		Images               []Image      `json:"images"`
+		IsPlayable           bool         `json:"is_playable"`
+		Languages            []string     `json:"languages"`
+		Name                 string       `json:"name"`
+		ReleaseDate          string       `json:"release_date"`
+		ReleaseDatePrecision string       `json:"release_date_precision"`
+		ResumePoint          ResumePoint  `json:"resume_point"`
+		Type                 string       `json:"type"`
+		URI                  string       `json:"uri"`
+		Restrictions         Restrictions `json:"restrictions"`
 		AudiobookSimple
 	}
 	Chapters struct {
-		Chapters []chapter `json:"chapters"`
+		Chapters []ChapterObject `json:"chapters"`
+	}
+)
+
+type (
+	PlaybackState struct {
+		Device               Device        `json:"device"`
+		RepeatState          string        `json:"repeat_state"`
+		ShuffleState         bool          `json:"shuffle_state"`
+		Context              ContextObject `json:"context"`
+		Timestamp            int           `json:"timestamp"`
+		ProgressMs           int           `json:"progress_ms"`
+		IsPlaying            bool          `json:"is_playing"`
+		Item                 PlayableItem  `json:"item"`
+		CurrentlyPlayingType string        `json:"currently_playing_type"`
+		Actions              Actions       `json:"actions"`
+	}
+	Devices struct {
+		Devices []Device `json:"devices"`
+	}
+	Queue struct {
+		CurrentlyPlaying PlayableItem   `json:"currently_playing"`
+		Queue            []PlayableItem `json:"queue"`
+	}
+
+	Categories struct {
+		Categories Paging[Categorie] `json:"categories"`
+	}
+	GenreSeeds struct {
+		Genres []string `json:"genres"`
+	}
+
+	SearchResults struct {
+		Tracks     Paging[TrackObject]           `json:"tracks"`
+		Artists    Paging[ArtistObject]          `json:"artists"`
+		Albums     Paging[AlbumSimpleObject]     `json:"albums"`
+		Playlists  Paging[PlaylistSimpleObject]  `json:"playlists"`
+		Shows      Paging[ShowSimpleObject]      `json:"shows"`
+		Episodes   Paging[EpisodeSimpleObject]   `json:"episodes"`
+		Audiobooks Paging[AudiobookSimpleObject] `json:"audiobooks"`
 	}
-	ChapterObject chapter
 )
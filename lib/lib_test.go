@@ -0,0 +1,177 @@
+package lib
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBatch(t *testing.T) {
+	errBatch := errors.New("batch failed")
+	items := []int{1, 2, 3, 4, 5, 6, 7}
+
+	tests := []struct {
+		name        string
+		size        int
+		concurrency int
+		failAt      []int // First item of the batches that fail.
+		want        []string
+		wantCalls   int32
+		wantErr     error
+	}{
+		{name: "sequential", size: 3, concurrency: 1, want: []string{"1", "2", "3", "4", "5", "6", "7"}, wantCalls: 3},
+		{name: "concurrent", size: 2, concurrency: 4, want: []string{"1", "2", "3", "4", "5", "6", "7"}, wantCalls: 4},
+		{name: "size below 1", size: 0, concurrency: 2, want: []string{"1", "2", "3", "4", "5", "6", "7"}, wantCalls: 7},
+		{name: "sequential stops at error", size: 2, concurrency: 1, failAt: []int{3}, wantCalls: 2, wantErr: errBatch},
+		{name: "concurrent runs all batches", size: 2, concurrency: 3, failAt: []int{3}, wantCalls: 4, wantErr: errBatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := atomic.Int32{}
+			got, err := Batch(items, tt.size, tt.concurrency, func(batch []int) ([]string, error) {
+				calls.Add(1)
+				// Later batches finish first, results must still be merged in input order.
+				time.Sleep(time.Millisecond * time.Duration(len(items)-batch[0]))
+				if slices.Contains(tt.failAt, batch[0]) {
+					return nil, errBatch
+				}
+				res := []string{}
+				for _, item := range batch {
+					res = append(res, strconv.Itoa(item))
+				}
+				return res, nil
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if calls.Load() != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls.Load(), tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestBatchFirstErrorInInputOrder(t *testing.T) {
+	errFirst, errSecond := errors.New("first"), errors.New("second")
+	_, err := Batch([]int{1, 2, 3}, 1, 3, func(batch []int) ([]int, error) {
+		switch batch[0] {
+		case 1:
+			time.Sleep(time.Millisecond * 10)
+			return nil, errFirst
+		case 3:
+			return nil, errSecond
+		}
+		return batch, nil
+	})
+	if !errors.Is(err, errFirst) {
+		t.Fatalf("err = %v, want %v", err, errFirst)
+	}
+}
+
+func TestPlayableItemUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         string
+		wantType     string
+		wantTrack    bool
+		wantEpisode  bool
+		wantURI      URI
+		wantName     string
+		wantDuration time.Duration
+	}{
+		{name: "track", data: `{"type":"track","id":"t","uri":"spotify:track:t","name":"Song","duration_ms":1500,"album":{"name":"Album"}}`, wantType: "track", wantTrack: true, wantURI: "spotify:track:t", wantName: "Song", wantDuration: time.Millisecond * 1500},
+		{name: "episode", data: `{"type":"episode","id":"e","uri":"spotify:episode:e","name":"Talk","duration_ms":60000,"show":{"name":"Show"}}`, wantType: "episode", wantEpisode: true, wantURI: "spotify:episode:e", wantName: "Talk", wantDuration: time.Minute},
+		{name: "unknown type", data: `{"type":"ad","name":"Ad"}`, wantType: "ad"},
+		{name: "null", data: `null`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := PlayableItem{}
+			if err := json.Unmarshal([]byte(tt.data), &item); err != nil {
+				t.Fatal(err)
+			}
+			track, isTrack := item.AsTrack()
+			episode, isEpisode := item.AsEpisode()
+			if item.Type != tt.wantType || isTrack != tt.wantTrack || isEpisode != tt.wantEpisode {
+				t.Fatalf("Type = %q, track %v, episode %v", item.Type, isTrack, isEpisode)
+			}
+			if item.URI() != tt.wantURI || item.Name() != tt.wantName || item.Duration() != tt.wantDuration {
+				t.Errorf("URI = %q, Name = %q, Duration = %v", item.URI(), item.Name(), item.Duration())
+			}
+			if isTrack && track.Album.Name != "Album" {
+				t.Errorf("Album.Name = %q", track.Album.Name)
+			}
+			if isEpisode && episode.Show.Name != "Show" {
+				t.Errorf("Show.Name = %q", episode.Show.Name)
+			}
+		})
+	}
+}
+
+func TestPlayableItemRoundTrip(t *testing.T) {
+	queue := Queue{}
+	data := `{"currently_playing":{"type":"episode","uri":"spotify:episode:e"},"queue":[{"type":"track","uri":"spotify:track:t"},{"type":"episode","uri":"spotify:episode:f"}]}`
+	if err := json.Unmarshal([]byte(data), &queue); err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := json.Marshal(queue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := Queue{}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.CurrentlyPlaying.URI() != "spotify:episode:e" || len(decoded.Queue) != 2 || decoded.Queue[0].Type != "track" || decoded.Queue[1].URI() != "spotify:episode:f" {
+		t.Errorf("round trip = %+v", decoded)
+	}
+}
+
+func TestAPIErrorIs(t *testing.T) {
+	sentinels := map[string]error{
+		"BadRequest": Errors.BadRequest, "Unauthorized": Errors.Unauthorized, "Forbidden": Errors.Forbidden, "NotFound": Errors.NotFound,
+		"RateLimited": Errors.RateLimited, "ServerError": Errors.ServerError, "NoActiveDevice": Errors.NoActiveDevice, "PremiumRequired": Errors.PremiumRequired,
+		"NoContent": Errors.NoContent, "InvalidState": Errors.InvalidState,
+	}
+
+	tests := []struct {
+		name string
+		err  APIError
+		want []string // Names of all sentinels the error matches.
+	}{
+		{name: "400", err: APIError{Status: 400}, want: []string{"BadRequest"}},
+		{name: "401", err: APIError{Status: 401}, want: []string{"Unauthorized"}},
+		{name: "403", err: APIError{Status: 403}, want: []string{"Forbidden"}},
+		{name: "403 premium required", err: APIError{Status: 403, Reason: "PREMIUM_REQUIRED"}, want: []string{"Forbidden", "PremiumRequired"}},
+		{name: "404", err: APIError{Status: 404}, want: []string{"NotFound"}},
+		{name: "404 no active device", err: APIError{Status: 404, Reason: "NO_ACTIVE_DEVICE"}, want: []string{"NotFound", "NoActiveDevice"}},
+		{name: "429", err: APIError{Status: 429}, want: []string{"RateLimited"}},
+		{name: "500", err: APIError{Status: 500}, want: []string{"ServerError"}},
+		{name: "502", err: APIError{Status: 502}, want: []string{"ServerError"}},
+		{name: "503", err: APIError{Status: 503}, want: []string{"ServerError"}},
+		{name: "504", err: APIError{Status: 504}, want: []string{"ServerError"}},
+		{name: "unmapped status", err: APIError{Status: 409}, want: []string{}},
+		{name: "unknown reason", err: APIError{Status: 403, Reason: "UNKNOWN"}, want: []string{"Forbidden"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error = &tt.err
+			for name, sentinel := range sentinels {
+				if got, want := errors.Is(err, sentinel), slices.Contains(tt.want, name); got != want {
+					t.Errorf("errors.Is(%v, %s) = %v, want %v", err, name, got, want)
+				}
+			}
+			if wrapped := fmt.Errorf("wrapped: %w", err); !errors.Is(wrapped, err) || (len(tt.want) > 0 && !errors.Is(wrapped, sentinels[tt.want[0]])) {
+				t.Errorf("wrapped %v does not match", err)
+			}
+		})
+	}
+}
@@ -1,21 +1,26 @@
 package markets
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/HandyGold75/gotify/lib"
 )
 
 type Markets struct {
-	Send func(method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)
+	Send func(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)
 }
 
-func New(send func(method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)) Markets {
+func New(send func(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)) Markets {
 	return Markets{Send: send}
 }
 
 func (s *Markets) GetAvailableMarkets() ([]string, error) {
-	res, err := s.Send(lib.GET, "markets", [][2]string{}, []byte{})
+	return s.GetAvailableMarketsCtx(context.Background())
+}
+
+func (s *Markets) GetAvailableMarketsCtx(ctx context.Context) ([]string, error) {
+	res, err := s.Send(ctx, lib.GET, "markets", [][2]string{}, []byte{})
 	if err != nil {
 		return []string{}, err
 	}
@@ -0,0 +1,68 @@
+package gotify
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+type (
+	// Option configures a `GotifyPlayer` in `NewGotifyPlayer`, every scope is an option as well.
+	Option interface {
+		apply(gp *GotifyPlayer)
+	}
+
+	optionFunc func(gp *GotifyPlayer)
+)
+
+func (fn optionFunc) apply(gp *GotifyPlayer) { fn(gp) }
+
+func (scp scope) apply(gp *GotifyPlayer) {
+	gp.authCfg.Scopes = append(gp.authCfg.Scopes, string(scp))
+}
+
+// WithHTTPClient sends all requests, including token exchanges and refreshes, with cl.
+//
+// Once authenticated the transport of cl is wrapped to authorize requests, other settings like `Timeout` are kept.
+func WithHTTPClient(cl *http.Client) Option {
+	return optionFunc(func(gp *GotifyPlayer) {
+		if cl != nil {
+			gp.baseCl = cl
+		}
+	})
+}
+
+// WithAuthPrompt replaces the default "Login: ... Paste: " prompt, prompt is called with the URL the user should open to login.
+//
+// Without `WithAuthQRPrompt` QR codes requested by `AuthenticateHeadless` are not shown, as the default QR prompt prints to stdout.
+func WithAuthPrompt(prompt func(url string)) Option {
+	return optionFunc(func(gp *GotifyPlayer) {
+		if prompt != nil {
+			gp.authUserMsgCallback = prompt
+		}
+	})
+}
+
+// WithAuthQRPrompt replaces the default prompt of `AuthenticateHeadless` when a QR code is requested, prompt is called with the URL the user should open to login and its QR code as rendered by `QRCode`.
+func WithAuthQRPrompt(prompt func(url, qr string)) Option {
+	return optionFunc(func(gp *GotifyPlayer) { gp.authQRMsgCallback = prompt })
+}
+
+// WithBaseURL sends API requests to url instead of "https://api.spotify.com/v1".
+func WithBaseURL(url string) Option {
+	return optionFunc(func(gp *GotifyPlayer) { gp.URL = strings.TrimSuffix(url, "/") })
+}
+
+// WithAuthEndpoints replaces the Spotify accounts endpoints used to login and to request tokens.
+func WithAuthEndpoints(authURL, tokenURL string) Option {
+	return optionFunc(func(gp *GotifyPlayer) {
+		gp.authCfg.Endpoint = oauth2.Endpoint{AuthURL: authURL, TokenURL: tokenURL}
+	})
+}
+
+// oauthCtx makes oauth2 request tokens with the configured HTTP client.
+func (gp *GotifyPlayer) oauthCtx(ctx context.Context) context.Context {
+	return context.WithValue(ctx, oauth2.HTTPClient, gp.baseCl)
+}
@@ -1,6 +1,7 @@
 package gotify
 
 import (
+	"context"
 	"time"
 
 	"github.com/HandyGold75/gotify/lib"
@@ -14,3 +15,75 @@ func (gp *GotifyPlayer) Seek(position time.Duration) error { return gp.Player.Se
 func (gp *GotifyPlayer) Repeat(state lib.RepeatMode) error { return gp.Player.SetRepeatMode(state) }
 func (gp *GotifyPlayer) Volume(volume int) error           { return gp.Player.SetPlaybackVolume(volume) }
 func (gp *GotifyPlayer) Shuffle(state bool) error          { return gp.Player.TogglePlaybackShuffle(state) }
+func (gp *GotifyPlayer) PlayTracks(uris ...lib.URI) error  { return gp.Player.PlayTracks(uris...) }
+func (gp *GotifyPlayer) PlayContext(uri lib.URI, offset int) error {
+	return gp.Player.PlayContext(uri, offset)
+}
+
+func (gp *GotifyPlayer) PlayCtx(ctx context.Context) error {
+	return gp.Player.StartResumePlaybackCtx(ctx, time.Duration(-1))
+}
+func (gp *GotifyPlayer) PlayContextCtx(ctx context.Context, uri lib.URI, offset int) error {
+	return gp.Player.PlayContextCtx(ctx, uri, offset)
+}
+func (gp *GotifyPlayer) PlayTracksCtx(ctx context.Context, uris ...lib.URI) error {
+	return gp.Player.PlayTracksCtx(ctx, uris...)
+}
+func (gp *GotifyPlayer) PauseCtx(ctx context.Context) error { return gp.Player.PausePlaybackCtx(ctx) }
+func (gp *GotifyPlayer) NextCtx(ctx context.Context) error  { return gp.Player.SkipToNextCtx(ctx) }
+func (gp *GotifyPlayer) PreviousCtx(ctx context.Context) error {
+	return gp.Player.SkipToPreviousCtx(ctx)
+}
+func (gp *GotifyPlayer) SeekCtx(ctx context.Context, position time.Duration) error {
+	return gp.Player.SeekToPositionCtx(ctx, position)
+}
+func (gp *GotifyPlayer) RepeatCtx(ctx context.Context, state lib.RepeatMode) error {
+	return gp.Player.SetRepeatModeCtx(ctx, state)
+}
+func (gp *GotifyPlayer) VolumeCtx(ctx context.Context, volume int) error {
+	return gp.Player.SetPlaybackVolumeCtx(ctx, volume)
+}
+func (gp *GotifyPlayer) ShuffleCtx(ctx context.Context, state bool) error {
+	return gp.Player.TogglePlaybackShuffleCtx(ctx, state)
+}
+
+func (gp *GotifyPlayer) EnqueueURIs(uris ...lib.URI) error {
+	return gp.Player.AddItemsToPlaybackQueue(uris, gp.EnqueueInterval)
+}
+func (gp *GotifyPlayer) EnqueueAlbum(id string) error {
+	return gp.EnqueueAlbumCtx(context.Background(), id)
+}
+func (gp *GotifyPlayer) EnqueuePlaylist(id string) error {
+	return gp.EnqueuePlaylistCtx(context.Background(), id)
+}
+
+func (gp *GotifyPlayer) EnqueueURIsCtx(ctx context.Context, uris ...lib.URI) error {
+	return gp.Player.AddItemsToPlaybackQueueCtx(ctx, uris, gp.EnqueueInterval)
+}
+
+// EnqueueAlbumCtx adds all tracks of the album to the queue in album order.
+func (gp *GotifyPlayer) EnqueueAlbumCtx(ctx context.Context, id string) error {
+	uris := []lib.URI{}
+	for track, err := range gp.Albums.AllAlbumTracksCtx(ctx, id) {
+		if err != nil {
+			return err
+		}
+		uris = append(uris, lib.URI(track.URI))
+	}
+	return gp.EnqueueURIsCtx(ctx, uris...)
+}
+
+// EnqueuePlaylistCtx adds all tracks and episodes of the playlist to the queue in playlist order, skipping local and unavailable items.
+func (gp *GotifyPlayer) EnqueuePlaylistCtx(ctx context.Context, id string) error {
+	uris := []lib.URI{}
+	for item, err := range gp.Playlists.AllPlaylistItemsCtx(ctx, id, []string{}) {
+		if err != nil {
+			return err
+		}
+		if item.IsLocal || item.Track.URI() == "" {
+			continue
+		}
+		uris = append(uris, item.Track.URI())
+	}
+	return gp.EnqueueURIsCtx(ctx, uris...)
+}
@@ -0,0 +1,246 @@
+package player
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/HandyGold75/gotify/lib"
+)
+
+type (
+	// DeviceManager resolves devices and transfers playback between them, building upon `GetAvailableDevices` and `TransferPlayback`.
+	DeviceManager struct {
+		Player       *Player
+		Path         string        // File remembering the last device playback was transferred to, empty disables remembering.
+		PollInterval time.Duration // Delay between device checks while waiting for a device.
+		Timeout      time.Duration // Maximum time to wait for a device to become active after transferring playback.
+	}
+
+	lastDevice struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+)
+
+func NewDeviceManager(player *Player) DeviceManager {
+	return DeviceManager{Player: player, Path: "", PollInterval: time.Millisecond * 500, Timeout: time.Second * 10}
+}
+
+// Scopes: `ScopeUserReadPlaybackState`
+//
+// Find returns the device matching the ID or otherwise the name case insensitively, returns `lib.Errors.NotFound` if no device matches.
+func (dm *DeviceManager) Find(nameOrID string) (lib.Device, error) {
+	return dm.FindCtx(context.Background(), nameOrID)
+}
+
+// Scopes: `ScopeUserReadPlaybackState`
+//
+// FindCtx returns the device matching the ID or otherwise the name case insensitively, returns `lib.Errors.NotFound` if no device matches.
+func (dm *DeviceManager) FindCtx(ctx context.Context, nameOrID string) (lib.Device, error) {
+	data, err := dm.Player.GetAvailableDevicesCtx(ctx)
+	if err != nil {
+		return lib.Device{}, err
+	}
+	for _, dev := range data.Devices {
+		if dev.ID == nameOrID {
+			return dev, nil
+		}
+	}
+	for _, dev := range data.Devices {
+		if strings.EqualFold(dev.Name, nameOrID) {
+			return dev, nil
+		}
+	}
+	return lib.Device{}, fmt.Errorf("%w: device %s", lib.Errors.NotFound, nameOrID)
+}
+
+// Scopes: `ScopeUserReadPlaybackState`
+//
+// FindByType returns all devices of the type case insensitively, Ex: "Computer", "Smartphone" or "Speaker".
+func (dm *DeviceManager) FindByType(typ string) ([]lib.Device, error) {
+	return dm.FindByTypeCtx(context.Background(), typ)
+}
+
+// Scopes: `ScopeUserReadPlaybackState`
+//
+// FindByTypeCtx returns all devices of the type case insensitively, Ex: "Computer", "Smartphone" or "Speaker".
+func (dm *DeviceManager) FindByTypeCtx(ctx context.Context, typ string) ([]lib.Device, error) {
+	data, err := dm.Player.GetAvailableDevicesCtx(ctx)
+	if err != nil {
+		return []lib.Device{}, err
+	}
+	devs := []lib.Device{}
+	for _, dev := range data.Devices {
+		if strings.EqualFold(dev.Type, typ) {
+			devs = append(devs, dev)
+		}
+	}
+	return devs, nil
+}
+
+// Scopes: `ScopeUserReadPlaybackState`
+//
+// Active returns the currently active device, returns `lib.Errors.NoActiveDevice` if no device is active.
+func (dm *DeviceManager) Active() (lib.Device, error) {
+	return dm.ActiveCtx(context.Background())
+}
+
+// Scopes: `ScopeUserReadPlaybackState`
+//
+// ActiveCtx returns the currently active device, returns `lib.Errors.NoActiveDevice` if no device is active.
+func (dm *DeviceManager) ActiveCtx(ctx context.Context) (lib.Device, error) {
+	data, err := dm.Player.GetAvailableDevicesCtx(ctx)
+	if err != nil {
+		return lib.Device{}, err
+	}
+	for _, dev := range data.Devices {
+		if dev.IsActive {
+			return dev, nil
+		}
+	}
+	return lib.Device{}, lib.Errors.NoActiveDevice
+}
+
+// Requires premium.
+//
+// Scopes: `ScopeUserModifyPlaybackState`, `ScopeUserReadPlaybackState`
+//
+// Transfer playback to the device and wait until it is active, returns `lib.Errors.NoActiveDevice` if it did not become active within `Timeout`.
+//
+// On success the device is targeted by `Player` and remembered.
+// As this sets `Player.DeviceID` it must not run concurrently with other calls on `Player`, stop a running `Watcher` first or call it from `OnEvent`.
+func (dm *DeviceManager) Transfer(dev lib.Device, play bool) error {
+	return dm.TransferCtx(context.Background(), dev, play)
+}
+
+// Requires premium.
+//
+// Scopes: `ScopeUserModifyPlaybackState`, `ScopeUserReadPlaybackState`
+//
+// Transfer playback to the device and wait until it is active, returns `lib.Errors.NoActiveDevice` if it did not become active within `Timeout`.
+//
+// On success the device is targeted by `Player` and remembered.
+// As this sets `Player.DeviceID` it must not run concurrently with other calls on `Player`, stop a running `Watcher` first or call it from `OnEvent`.
+func (dm *DeviceManager) TransferCtx(ctx context.Context, dev lib.Device, play bool) error {
+	if err := dm.Player.TransferPlaybackCtx(ctx, dev.ID, play); err != nil {
+		return err
+	}
+	deadline := time.Now().Add(dm.Timeout)
+	for {
+		active, err := dm.ActiveCtx(ctx)
+		if err == nil && active.ID == dev.ID {
+			break
+		} else if err != nil && !errors.Is(err, lib.Errors.NoActiveDevice) {
+			return err
+		} else if time.Now().After(deadline) {
+			return fmt.Errorf("%w: device %s did not become active", lib.Errors.NoActiveDevice, dev.Name)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(dm.PollInterval):
+		}
+	}
+	dm.Player.DeviceID = dev.ID
+	return dm.remember(dev)
+}
+
+// Requires premium.
+//
+// Scopes: `ScopeUserModifyPlaybackState`, `ScopeUserReadPlaybackState`
+//
+// Wake the device by name or ID and transfer playback to it, retrying up to attempts times.
+//
+// Idle devices may drop from the available devices until they are used again, every attempt looks the device up again after waiting `PollInterval`.
+func (dm *DeviceManager) WakeAndTransfer(nameOrID string, play bool, attempts int) error {
+	return dm.WakeAndTransferCtx(context.Background(), nameOrID, play, attempts)
+}
+
+// Requires premium.
+//
+// Scopes: `ScopeUserModifyPlaybackState`, `ScopeUserReadPlaybackState`
+//
+// Wake the device by name or ID and transfer playback to it, retrying up to attempts times.
+//
+// Idle devices may drop from the available devices until they are used again, every attempt looks the device up again after waiting `PollInterval`.
+func (dm *DeviceManager) WakeAndTransferCtx(ctx context.Context, nameOrID string, play bool, attempts int) error {
+	var err error
+	for attempt := range max(1, attempts) {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(dm.PollInterval):
+			}
+		}
+		dev, findErr := dm.FindCtx(ctx, nameOrID)
+		if err = findErr; err != nil {
+			continue
+		}
+		if err = dm.TransferCtx(ctx, dev, play); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// Last returns the last device playback was transferred to, returns `lib.Errors.NoContent` if no device is remembered.
+//
+// The device may be stale, only `ID` and `Name` are set.
+func (dm *DeviceManager) Last() (lib.Device, error) {
+	if dm.Path == "" {
+		return lib.Device{}, lib.Errors.NoContent
+	}
+	data, err := os.ReadFile(dm.Path)
+	if os.IsNotExist(err) {
+		return lib.Device{}, lib.Errors.NoContent
+	} else if err != nil {
+		return lib.Device{}, err
+	}
+	last := lastDevice{}
+	if err := json.Unmarshal(data, &last); err != nil {
+		return lib.Device{}, err
+	}
+	return lib.Device{ID: last.ID, Name: last.Name}, nil
+}
+
+// Requires premium.
+//
+// Scopes: `ScopeUserModifyPlaybackState`, `ScopeUserReadPlaybackState`
+//
+// Transfer playback to the last remembered device, by ID and falling back to name as device IDs may change between sessions.
+func (dm *DeviceManager) ResumeLast(play bool, attempts int) error {
+	return dm.ResumeLastCtx(context.Background(), play, attempts)
+}
+
+// Requires premium.
+//
+// Scopes: `ScopeUserModifyPlaybackState`, `ScopeUserReadPlaybackState`
+//
+// Transfer playback to the last remembered device, by ID and falling back to name as device IDs may change between sessions.
+func (dm *DeviceManager) ResumeLastCtx(ctx context.Context, play bool, attempts int) error {
+	last, err := dm.Last()
+	if err != nil {
+		return err
+	}
+	if err := dm.WakeAndTransferCtx(ctx, last.ID, play, attempts); err == nil || last.Name == "" {
+		return err
+	}
+	return dm.WakeAndTransferCtx(ctx, last.Name, play, 1)
+}
+
+func (dm *DeviceManager) remember(dev lib.Device) error {
+	if dm.Path == "" {
+		return nil
+	}
+	data, err := json.Marshal(lastDevice{ID: dev.ID, Name: dev.Name})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dm.Path, data, 0o600)
+}
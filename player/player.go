@@ -1,7 +1,11 @@
 package player
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"iter"
+	"slices"
 	"strconv"
 	"time"
 
@@ -10,55 +14,35 @@ import (
 
 type (
 	Player struct {
-		Send     func(method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)
+		Send     func(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)
 		DeviceID string
 		Market   string // An ISO 3166-1 alpha-2 country code, https://en.wikipedia.org/wiki/ISO_3166-1_alpha-2
 	}
 
-	getPlaybackState struct {
-		Device       lib.Device `json:"device"`
-		RepeatState  string     `json:"repeat_state"`
-		ShuffleState bool       `json:"shuffle_state"`
-		lib.Context
-		Timestamp  int  `json:"timestamp"`
-		ProgressMs int  `json:"progress_ms"`
-		IsPlaying  bool `json:"is_playing"`
-		Item       struct {
-			lib.TrackObject
-			lib.EpisodeObject
-		} `json:"item"`
-		CurrentlyPlayingType string      `json:"currently_playing_type"`
-		Actions              lib.Actions `json:"actions"`
+	// PlayRequest is the body of `Play`, leaving both `ContextURI` and `URIs` empty resumes the current playback.
+	PlayRequest struct {
+		ContextURI lib.URI       // Album, artist, playlist, show or audiobook to play, can not be combined with `URIs`.
+		URIs       []lib.URI     // Tracks or episodes to play, can not be combined with `ContextURI`.
+		Offset     *PlayOffset   // Item to start at, requires an album or playlist `ContextURI` or `URIs`, may be nil.
+		Position   time.Duration // Position to start the first item at, zero starts at the beginning or keeps the position when resuming.
 	}
-
-	getAvailableDevices struct {
-		Devices []lib.Device `json:"devices"`
-	}
-
-	getCurrentlyPlayingTrack getPlaybackState
-
-	getRecentlyPlayedTracks struct {
-		lib.ItemsCursorsHeaders
-		Items []struct {
-			lib.Track
-			PlayedAt string `json:"played_at"`
-			lib.Context
-		} `json:"items"`
+	// PlayOffset selects the item to start at, by `URI` if set and by `Position` otherwise.
+	PlayOffset struct {
+		Position int     // Zero based index of the item in the context or `URIs`.
+		URI      lib.URI // URI of the item in the context or `URIs`.
 	}
 
-	getTheUsersQueue struct {
-		CurrentlyPlaying struct {
-			lib.TrackObject
-			lib.EpisodeObject
-		} `json:"currently_playing"`
-		Queue []struct {
-			lib.TrackObject
-			lib.EpisodeObject
-		} `json:"queue"`
+	// QueueDiff holds the changes between two results of `GetTheUsersQueue`, items are compared by URI.
+	//
+	// Note that Spotify only returns the next few items, including upcoming items of the context, so items appearing at the end of the queue are reported as added as well.
+	QueueDiff struct {
+		CurrentlyPlayingChanged bool               // The currently playing item changed.
+		Added                   []lib.PlayableItem // Items of the new queue missing from the previous queue, in queue order.
+		Removed                 []lib.PlayableItem // Items of the previous queue missing from the new queue (played, skipped or removed), in queue order.
 	}
 )
 
-func New(send func(method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)) Player {
+func New(send func(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)) Player {
 	return Player{
 		Send:     send,
 		DeviceID: "", Market: "",
@@ -66,12 +50,23 @@ func New(send func(method lib.HTTPMethod, action string, options [][2]string, bo
 }
 
 // Scopes: `ScopeUserReadPlaybackState`
-func (s *Player) GetPlaybackState() (getPlaybackState, error) {
-	res, err := s.Send(lib.GET, "player", [][2]string{{"market", s.Market}}, []byte{})
+//
+// Returns `lib.Errors.NoContent` when playback is not available or active.
+func (s *Player) GetPlaybackState() (lib.PlaybackState, error) {
+	return s.GetPlaybackStateCtx(context.Background())
+}
+
+// Scopes: `ScopeUserReadPlaybackState`
+//
+// Returns `lib.Errors.NoContent` when playback is not available or active.
+func (s *Player) GetPlaybackStateCtx(ctx context.Context) (lib.PlaybackState, error) {
+	res, err := s.Send(ctx, lib.GET, "player", [][2]string{{"market", s.Market}, {"additional_types", "track,episode"}}, []byte{})
 	if err != nil {
-		return getPlaybackState{}, err
+		return lib.PlaybackState{}, err
+	} else if len(res) == 0 {
+		return lib.PlaybackState{}, lib.Errors.NoContent
 	}
-	data := getPlaybackState{}
+	data := lib.PlaybackState{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
@@ -80,32 +75,55 @@ func (s *Player) GetPlaybackState() (getPlaybackState, error) {
 //
 // Scopes: `ScopeUserModifyPlaybackState`
 func (s *Player) TransferPlayback(deviceID string, play bool) error {
-	body, err := json.Marshal(map[string]any{"device_ids": deviceID, "play": play})
+	return s.TransferPlaybackCtx(context.Background(), deviceID, play)
+}
+
+// Requires premium.
+//
+// Scopes: `ScopeUserModifyPlaybackState`
+func (s *Player) TransferPlaybackCtx(ctx context.Context, deviceID string, play bool) error {
+	body, err := json.Marshal(map[string]any{"device_ids": []string{deviceID}, "play": play})
 	if err != nil {
 		return err
 	}
-	_, err = s.Send(lib.PUT, "player", [][2]string{}, body)
+	_, err = s.Send(ctx, lib.PUT, "player", [][2]string{}, body)
 	return err
 }
 
 // Scopes: `ScopeUserReadPlaybackState`
-func (s *Player) GetAvailableDevices() (getAvailableDevices, error) {
-	res, err := s.Send(lib.GET, "player/devices", [][2]string{}, []byte{})
+func (s *Player) GetAvailableDevices() (lib.Devices, error) {
+	return s.GetAvailableDevicesCtx(context.Background())
+}
+
+// Scopes: `ScopeUserReadPlaybackState`
+func (s *Player) GetAvailableDevicesCtx(ctx context.Context) (lib.Devices, error) {
+	res, err := s.Send(ctx, lib.GET, "player/devices", [][2]string{}, []byte{})
 	if err != nil {
-		return getAvailableDevices{}, err
+		return lib.Devices{}, err
 	}
-	data := getAvailableDevices{}
+	data := lib.Devices{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
 
 // Scopes: `ScopeUserReadCurrentlyPlaying`
-func (s *Player) GetCurrentlyPlayingTrack() (getCurrentlyPlayingTrack, error) {
-	res, err := s.Send(lib.GET, "player/currently-playing", [][2]string{{"market", s.Market}}, []byte{})
+//
+// Returns `lib.Errors.NoContent` when playback is not available or active.
+func (s *Player) GetCurrentlyPlayingTrack() (lib.PlaybackState, error) {
+	return s.GetCurrentlyPlayingTrackCtx(context.Background())
+}
+
+// Scopes: `ScopeUserReadCurrentlyPlaying`
+//
+// Returns `lib.Errors.NoContent` when playback is not available or active.
+func (s *Player) GetCurrentlyPlayingTrackCtx(ctx context.Context) (lib.PlaybackState, error) {
+	res, err := s.Send(ctx, lib.GET, "player/currently-playing", [][2]string{{"market", s.Market}, {"additional_types", "track,episode"}}, []byte{})
 	if err != nil {
-		return getCurrentlyPlayingTrack{}, err
+		return lib.PlaybackState{}, err
+	} else if len(res) == 0 {
+		return lib.PlaybackState{}, lib.Errors.NoContent
 	}
-	data := getCurrentlyPlayingTrack{}
+	data := lib.PlaybackState{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
@@ -116,16 +134,20 @@ func (s *Player) GetCurrentlyPlayingTrack() (getCurrentlyPlayingTrack, error) {
 //
 // Use `time.Duration(-1)` to disable this filter.
 func (s *Player) StartResumePlayback(position time.Duration) error {
-	value := ""
-	if time.Duration(0) > position {
-		value = strconv.Itoa(int(position.Milliseconds()))
-	}
-	body, err := json.Marshal(map[string]any{"position_ms": value})
-	if err != nil {
-		return err
+	return s.StartResumePlaybackCtx(context.Background(), position)
+}
+
+// Requires premium.
+//
+// Scopes: `ScopeUserModifyPlaybackState`
+//
+// Use `time.Duration(-1)` to disable this filter.
+func (s *Player) StartResumePlaybackCtx(ctx context.Context, position time.Duration) error {
+	req := map[string]any{}
+	if position >= 0 {
+		req["position_ms"] = position.Milliseconds()
 	}
-	_, err = s.Send(lib.PUT, "player/play", [][2]string{{"device_id", s.DeviceID}}, body)
-	return err
+	return s.StartResumePlaybackRawCtx(ctx, req)
 }
 
 // Requires premium.
@@ -144,19 +166,163 @@ func (s *Player) StartResumePlayback(position time.Duration) error {
 //	    "position_ms": 0
 //	}
 func (s *Player) StartResumePlaybackRaw(req map[string]any) error {
+	return s.StartResumePlaybackRawCtx(context.Background(), req)
+}
+
+// Requires premium.
+//
+// Scopes: `ScopeUserModifyPlaybackState`
+//
+// Body:
+//
+//	{
+//	    "context_uri": "spotify:album:5ht7ItJgpBH7W6vJ5BqpPr",
+//	    "uris": ["spotify:track:4iV5W9uYEdYUVa79Axb7Rh", "spotify:track:1301WleyT98MSxVHPZCA6M"],
+//	    "offset": {
+//	        "position": 5,
+//	        "uri": "spotify:track:1301WleyT98MSxVHPZCA6M"
+//	    },
+//	    "position_ms": 0
+//	}
+func (s *Player) StartResumePlaybackRawCtx(ctx context.Context, req map[string]any) error {
 	body, err := json.Marshal(req)
 	if err != nil {
 		return err
 	}
-	_, err = s.Send(lib.PUT, "player/play", [][2]string{{"device_id", s.DeviceID}}, body)
+	_, err = s.Send(ctx, lib.PUT, "player/play", [][2]string{{"device_id", s.DeviceID}}, body)
 	return err
 }
 
+// Validate checks the request before it is sent, returns `lib.Errors.InvalidRequest` describing the first problem.
+func (req PlayRequest) Validate() error {
+	if req.ContextURI != "" && len(req.URIs) > 0 {
+		return fmt.Errorf("%w: context uri and uris are mutually exclusive", lib.Errors.InvalidRequest)
+	} else if req.Position < 0 {
+		return fmt.Errorf("%w: negative position", lib.Errors.InvalidRequest)
+	}
+	if req.ContextURI != "" && !slices.Contains([]lib.URIResource{lib.URIResourceAlbum, lib.URIResourceArtist, lib.URIResourcePlaylist, lib.URIResourceShow, lib.URIResourceAudiobook}, req.ContextURI.Resource()) {
+		return fmt.Errorf("%w: context uri %s", lib.Errors.InvalidRequest, req.ContextURI)
+	}
+	for _, uri := range req.URIs {
+		if !slices.Contains([]lib.URIResource{lib.URIResourceTrack, lib.URIResourceEpisode}, uri.Resource()) {
+			return fmt.Errorf("%w: uri %s", lib.Errors.InvalidRequest, uri)
+		}
+	}
+	if req.Offset == nil {
+		return nil
+	}
+	if req.ContextURI == "" && len(req.URIs) == 0 {
+		return fmt.Errorf("%w: offset without context uri or uris", lib.Errors.InvalidRequest)
+	} else if req.ContextURI != "" && !slices.Contains([]lib.URIResource{lib.URIResourceAlbum, lib.URIResourcePlaylist}, req.ContextURI.Resource()) {
+		return fmt.Errorf("%w: offset with context uri %s", lib.Errors.InvalidRequest, req.ContextURI)
+	} else if req.Offset.Position < 0 {
+		return fmt.Errorf("%w: negative offset position", lib.Errors.InvalidRequest)
+	} else if req.Offset.URI != "" && len(req.URIs) > 0 && !slices.Contains(req.URIs, req.Offset.URI) {
+		return fmt.Errorf("%w: offset uri %s not in uris", lib.Errors.InvalidRequest, req.Offset.URI)
+	}
+	return nil
+}
+
+func (req PlayRequest) MarshalJSON() ([]byte, error) {
+	body := map[string]any{}
+	if req.ContextURI != "" {
+		body["context_uri"] = req.ContextURI
+	}
+	if len(req.URIs) > 0 {
+		body["uris"] = req.URIs
+	}
+	if req.Offset != nil && req.Offset.URI != "" {
+		body["offset"] = map[string]any{"uri": req.Offset.URI}
+	} else if req.Offset != nil {
+		body["offset"] = map[string]any{"position": req.Offset.Position}
+	}
+	if req.Position > 0 {
+		body["position_ms"] = req.Position.Milliseconds()
+	}
+	return json.Marshal(body)
+}
+
+// Requires premium.
+//
+// Scopes: `ScopeUserModifyPlaybackState`
+//
+// The request is validated before it is sent.
+func (s *Player) Play(req PlayRequest) error {
+	return s.PlayCtx(context.Background(), req)
+}
+
+// Requires premium.
+//
+// Scopes: `ScopeUserModifyPlaybackState`
+//
+// The request is validated before it is sent.
+func (s *Player) PlayCtx(ctx context.Context, req PlayRequest) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	_, err = s.Send(ctx, lib.PUT, "player/play", [][2]string{{"device_id", s.DeviceID}}, body)
+	return err
+}
+
+// Requires premium.
+//
+// Scopes: `ScopeUserModifyPlaybackState`
+//
+// Play the album, artist, playlist, show or audiobook uri starting at the zero based offset, the offset is only supported by albums and playlists.
+func (s *Player) PlayContext(uri lib.URI, offset int) error {
+	return s.PlayContextCtx(context.Background(), uri, offset)
+}
+
+// Requires premium.
+//
+// Scopes: `ScopeUserModifyPlaybackState`
+//
+// Play the album, artist, playlist, show or audiobook uri starting at the zero based offset, the offset is only supported by albums and playlists.
+func (s *Player) PlayContextCtx(ctx context.Context, uri lib.URI, offset int) error {
+	req := PlayRequest{ContextURI: uri}
+	if offset != 0 {
+		req.Offset = &PlayOffset{Position: offset}
+	}
+	return s.PlayCtx(ctx, req)
+}
+
+// Requires premium.
+//
+// Scopes: `ScopeUserModifyPlaybackState`
+//
+// Play the tracks or episodes in order, replacing the current context.
+func (s *Player) PlayTracks(uris ...lib.URI) error {
+	return s.PlayTracksCtx(context.Background(), uris...)
+}
+
+// Requires premium.
+//
+// Scopes: `ScopeUserModifyPlaybackState`
+//
+// Play the tracks or episodes in order, replacing the current context.
+func (s *Player) PlayTracksCtx(ctx context.Context, uris ...lib.URI) error {
+	if len(uris) == 0 {
+		return fmt.Errorf("%w: no uris", lib.Errors.InvalidRequest)
+	}
+	return s.PlayCtx(ctx, PlayRequest{URIs: uris})
+}
+
 // Requires premium.
 //
 // Scopes: `ScopeUserModifyPlaybackState`
 func (s *Player) PausePlayback() error {
-	_, err := s.Send(lib.PUT, "player/pause", [][2]string{{"device_id", s.DeviceID}}, []byte{})
+	return s.PausePlaybackCtx(context.Background())
+}
+
+// Requires premium.
+//
+// Scopes: `ScopeUserModifyPlaybackState`
+func (s *Player) PausePlaybackCtx(ctx context.Context) error {
+	_, err := s.Send(ctx, lib.PUT, "player/pause", [][2]string{{"device_id", s.DeviceID}}, []byte{})
 	return err
 }
 
@@ -164,7 +330,14 @@ func (s *Player) PausePlayback() error {
 //
 // Scopes: `ScopeUserModifyPlaybackState`
 func (s *Player) SkipToNext() error {
-	_, err := s.Send(lib.POST, "player/next", [][2]string{{"device_id", s.DeviceID}}, []byte{})
+	return s.SkipToNextCtx(context.Background())
+}
+
+// Requires premium.
+//
+// Scopes: `ScopeUserModifyPlaybackState`
+func (s *Player) SkipToNextCtx(ctx context.Context) error {
+	_, err := s.Send(ctx, lib.POST, "player/next", [][2]string{{"device_id", s.DeviceID}}, []byte{})
 	return err
 }
 
@@ -172,7 +345,14 @@ func (s *Player) SkipToNext() error {
 //
 // Scopes: `ScopeUserModifyPlaybackState`
 func (s *Player) SkipToPrevious() error {
-	_, err := s.Send(lib.POST, "player/previous", [][2]string{{"device_id", s.DeviceID}}, []byte{})
+	return s.SkipToPreviousCtx(context.Background())
+}
+
+// Requires premium.
+//
+// Scopes: `ScopeUserModifyPlaybackState`
+func (s *Player) SkipToPreviousCtx(ctx context.Context) error {
+	_, err := s.Send(ctx, lib.POST, "player/previous", [][2]string{{"device_id", s.DeviceID}}, []byte{})
 	return err
 }
 
@@ -180,7 +360,14 @@ func (s *Player) SkipToPrevious() error {
 //
 // Scopes: `ScopeUserModifyPlaybackState`
 func (s *Player) SeekToPosition(position time.Duration) error {
-	_, err := s.Send(lib.PUT, "player/seek", [][2]string{{"device_id", s.DeviceID}, {"position_ms", strconv.Itoa(int(position.Milliseconds()))}}, []byte{})
+	return s.SeekToPositionCtx(context.Background(), position)
+}
+
+// Requires premium.
+//
+// Scopes: `ScopeUserModifyPlaybackState`
+func (s *Player) SeekToPositionCtx(ctx context.Context, position time.Duration) error {
+	_, err := s.Send(ctx, lib.PUT, "player/seek", [][2]string{{"device_id", s.DeviceID}, {"position_ms", strconv.Itoa(int(position.Milliseconds()))}}, []byte{})
 	return err
 }
 
@@ -188,7 +375,14 @@ func (s *Player) SeekToPosition(position time.Duration) error {
 //
 // Scopes: `ScopeUserModifyPlaybackState`
 func (s *Player) SetRepeatMode(state lib.RepeatMode) error {
-	_, err := s.Send(lib.PUT, "player/repeat", [][2]string{{"device_id", s.DeviceID}, {"state", string(state)}}, []byte{})
+	return s.SetRepeatModeCtx(context.Background(), state)
+}
+
+// Requires premium.
+//
+// Scopes: `ScopeUserModifyPlaybackState`
+func (s *Player) SetRepeatModeCtx(ctx context.Context, state lib.RepeatMode) error {
+	_, err := s.Send(ctx, lib.PUT, "player/repeat", [][2]string{{"device_id", s.DeviceID}, {"state", string(state)}}, []byte{})
 	return err
 }
 
@@ -196,7 +390,14 @@ func (s *Player) SetRepeatMode(state lib.RepeatMode) error {
 //
 // Scopes: `ScopeUserModifyPlaybackState`
 func (s *Player) SetPlaybackVolume(volume int) error {
-	_, err := s.Send(lib.PUT, "player/volume", [][2]string{{"device_id", s.DeviceID}, {"volume_percent", strconv.Itoa(max(0, min(100, volume)))}}, []byte{})
+	return s.SetPlaybackVolumeCtx(context.Background(), volume)
+}
+
+// Requires premium.
+//
+// Scopes: `ScopeUserModifyPlaybackState`
+func (s *Player) SetPlaybackVolumeCtx(ctx context.Context, volume int) error {
+	_, err := s.Send(ctx, lib.PUT, "player/volume", [][2]string{{"device_id", s.DeviceID}, {"volume_percent", strconv.Itoa(max(0, min(100, volume)))}}, []byte{})
 	return err
 }
 
@@ -204,7 +405,14 @@ func (s *Player) SetPlaybackVolume(volume int) error {
 //
 // Scopes: `ScopeUserModifyPlaybackState`
 func (s *Player) TogglePlaybackShuffle(state bool) error {
-	_, err := s.Send(lib.PUT, "player/shuffle", [][2]string{{"device_id", s.DeviceID}, {"state", strconv.FormatBool(state)}}, []byte{})
+	return s.TogglePlaybackShuffleCtx(context.Background(), state)
+}
+
+// Requires premium.
+//
+// Scopes: `ScopeUserModifyPlaybackState`
+func (s *Player) TogglePlaybackShuffleCtx(ctx context.Context, state bool) error {
+	_, err := s.Send(ctx, lib.PUT, "player/shuffle", [][2]string{{"device_id", s.DeviceID}, {"state", strconv.FormatBool(state)}}, []byte{})
 	return err
 }
 
@@ -212,29 +420,76 @@ func (s *Player) TogglePlaybackShuffle(state bool) error {
 //
 // Return items after stamp if after is true, otherwise returns items before time.
 // Use `time.Time{}` to disable this filter.
-func (s *Player) GetRecentlyPlayedTracks(limit int, stamp time.Time, after bool) (getRecentlyPlayedTracks, error) {
-	key, value := "before", strconv.Itoa(int(stamp.Unix()))
-	if stamp.Unix() == (time.Time{}.Unix()) {
+func (s *Player) GetRecentlyPlayedTracks(limit int, stamp time.Time, after bool) (lib.CursorPaging[lib.PlayedTrack], error) {
+	return s.GetRecentlyPlayedTracksCtx(context.Background(), limit, stamp, after)
+}
+
+// Scopes: `ScopeUserReadRecentlyPlayed`
+//
+// Return items after stamp if after is true, otherwise returns items before time.
+// Use `time.Time{}` to disable this filter.
+func (s *Player) GetRecentlyPlayedTracksCtx(ctx context.Context, limit int, stamp time.Time, after bool) (lib.CursorPaging[lib.PlayedTrack], error) {
+	key, value := "before", strconv.FormatInt(stamp.UnixMilli(), 10)
+	if stamp.IsZero() {
 		value = ""
 	} else if after {
 		key = "after"
 	}
-	res, err := s.Send(lib.GET, "player/recently-played", [][2]string{{"limit", strconv.Itoa(max(1, min(50, limit)))}, {key, value}}, []byte{})
+	res, err := s.Send(ctx, lib.GET, "player/recently-played", [][2]string{{"limit", strconv.Itoa(max(1, min(50, limit)))}, {key, value}}, []byte{})
 	if err != nil {
-		return getRecentlyPlayedTracks{}, err
+		return lib.CursorPaging[lib.PlayedTrack]{}, err
 	}
-	data := getRecentlyPlayedTracks{}
+	data := lib.CursorPaging[lib.PlayedTrack]{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
 
+// Scopes: `ScopeUserReadRecentlyPlayed`
+//
+// Iterates from the most recently played track back in time, stops at the first item played before since.
+// Use `time.Time{}` to disable this filter.
+func (s *Player) AllRecentlyPlayedTracks(since time.Time) iter.Seq2[lib.PlayedTrack, error] {
+	return s.AllRecentlyPlayedTracksCtx(context.Background(), since)
+}
+
+// Scopes: `ScopeUserReadRecentlyPlayed`
+//
+// Iterates from the most recently played track back in time, stops at the first item played before since.
+// Use `time.Time{}` to disable this filter.
+func (s *Player) AllRecentlyPlayedTracksCtx(ctx context.Context, since time.Time) iter.Seq2[lib.PlayedTrack, error] {
+	return func(yield func(lib.PlayedTrack, error) bool) {
+		for item, err := range lib.PaginateCursor(func(cursor string) ([]lib.PlayedTrack, string, error) {
+			stamp := time.Time{}
+			if ms, err := strconv.ParseInt(cursor, 10, 64); err == nil {
+				stamp = time.UnixMilli(ms)
+			}
+			data, err := s.GetRecentlyPlayedTracksCtx(ctx, 50, stamp, false)
+			return data.Items, data.Cursors.Before, err
+		}) {
+			if err == nil && !since.IsZero() {
+				if playedAt, e := time.Parse(time.RFC3339, item.PlayedAt); e == nil && playedAt.Before(since) {
+					return
+				}
+			}
+			if !yield(item, err) {
+				return
+			}
+		}
+	}
+}
+
+// Scopes: `ScopeUserReadCurrentlyPlaying`, `ScopeUserReadPlaybackState`
+func (s *Player) GetTheUsersQueue() (lib.Queue, error) {
+	return s.GetTheUsersQueueCtx(context.Background())
+}
+
 // Scopes: `ScopeUserReadCurrentlyPlaying`, `ScopeUserReadPlaybackState`
-func (s *Player) GetTheUsersQueue() (getTheUsersQueue, error) {
-	res, err := s.Send(lib.GET, "player/queue", [][2]string{}, []byte{})
+func (s *Player) GetTheUsersQueueCtx(ctx context.Context) (lib.Queue, error) {
+	res, err := s.Send(ctx, lib.GET, "player/queue", [][2]string{}, []byte{})
 	if err != nil {
-		return getTheUsersQueue{}, err
+		return lib.Queue{}, err
 	}
-	data := getTheUsersQueue{}
+	data := lib.Queue{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
@@ -243,6 +498,75 @@ func (s *Player) GetTheUsersQueue() (getTheUsersQueue, error) {
 //
 // Scopes: `ScopeUserModifyPlaybackState`
 func (s *Player) AddItemToPlaybackQueue(uri lib.URI) error {
-	_, err := s.Send(lib.POST, "player", [][2]string{{"device_id", s.DeviceID}, {"uri", string(uri)}}, []byte{})
+	return s.AddItemToPlaybackQueueCtx(context.Background(), uri)
+}
+
+// Requires premium.
+//
+// Scopes: `ScopeUserModifyPlaybackState`
+func (s *Player) AddItemToPlaybackQueueCtx(ctx context.Context, uri lib.URI) error {
+	_, err := s.Send(ctx, lib.POST, "player/queue", [][2]string{{"device_id", s.DeviceID}, {"uri", string(uri)}}, []byte{})
 	return err
 }
+
+// Requires premium.
+//
+// Scopes: `ScopeUserModifyPlaybackState`
+//
+// Adds the uris to the queue in order, waiting interval between requests to stay within the rate limit.
+//
+// Stops at the first error, the items before it remain queued.
+func (s *Player) AddItemsToPlaybackQueue(uris []lib.URI, interval time.Duration) error {
+	return s.AddItemsToPlaybackQueueCtx(context.Background(), uris, interval)
+}
+
+// Requires premium.
+//
+// Scopes: `ScopeUserModifyPlaybackState`
+//
+// Adds the uris to the queue in order, waiting interval between requests to stay within the rate limit.
+//
+// Stops at the first error, the items before it remain queued.
+func (s *Player) AddItemsToPlaybackQueueCtx(ctx context.Context, uris []lib.URI, interval time.Duration) error {
+	for i, uri := range uris {
+		if i > 0 && interval > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(interval):
+			}
+		}
+		if err := s.AddItemToPlaybackQueueCtx(ctx, uri); err != nil {
+			return fmt.Errorf("queue %s: %w", uri, err)
+		}
+	}
+	return nil
+}
+
+// DiffQueue returns the changes from prev to the queue q, both as returned by `GetTheUsersQueue`.
+func DiffQueue(prev, q lib.Queue) QueueDiff {
+	diff := QueueDiff{CurrentlyPlayingChanged: q.CurrentlyPlaying.URI() != prev.CurrentlyPlaying.URI(), Added: []lib.PlayableItem{}, Removed: []lib.PlayableItem{}}
+	count := func(items []lib.PlayableItem) map[lib.URI]int {
+		uris := map[lib.URI]int{}
+		for _, item := range items {
+			uris[item.URI()]++
+		}
+		return uris
+	}
+	prevURIs, newURIs := count(prev.Queue), count(q.Queue)
+	for _, item := range q.Queue {
+		if prevURIs[item.URI()] > 0 {
+			prevURIs[item.URI()]--
+			continue
+		}
+		diff.Added = append(diff.Added, item)
+	}
+	for _, item := range prev.Queue {
+		if newURIs[item.URI()] > 0 {
+			newURIs[item.URI()]--
+			continue
+		}
+		diff.Removed = append(diff.Removed, item)
+	}
+	return diff
+}
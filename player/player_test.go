@@ -0,0 +1,115 @@
+package player
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/HandyGold75/gotify/lib"
+)
+
+func TestPlayRequestValidate(t *testing.T) {
+	album, playlist, artist := lib.URI("spotify:album:a"), lib.URI("spotify:playlist:p"), lib.URI("spotify:artist:r")
+	track, episode := lib.URI("spotify:track:t"), lib.URI("spotify:episode:e")
+
+	tests := []struct {
+		name    string
+		req     PlayRequest
+		wantErr bool
+	}{
+		{name: "resume", req: PlayRequest{}},
+		{name: "context", req: PlayRequest{ContextURI: artist}},
+		{name: "uris", req: PlayRequest{URIs: []lib.URI{track, episode}, Position: time.Second}},
+		{name: "context with offset position", req: PlayRequest{ContextURI: album, Offset: &PlayOffset{Position: 2}}},
+		{name: "context with offset uri", req: PlayRequest{ContextURI: playlist, Offset: &PlayOffset{URI: track}}},
+		{name: "uris with offset uri", req: PlayRequest{URIs: []lib.URI{track, episode}, Offset: &PlayOffset{URI: episode}}},
+		{name: "context and uris", req: PlayRequest{ContextURI: album, URIs: []lib.URI{track}}, wantErr: true},
+		{name: "negative position", req: PlayRequest{URIs: []lib.URI{track}, Position: -time.Second}, wantErr: true},
+		{name: "track as context", req: PlayRequest{ContextURI: track}, wantErr: true},
+		{name: "album in uris", req: PlayRequest{URIs: []lib.URI{album}}, wantErr: true},
+		{name: "offset without context", req: PlayRequest{Offset: &PlayOffset{Position: 1}}, wantErr: true},
+		{name: "offset with artist context", req: PlayRequest{ContextURI: artist, Offset: &PlayOffset{Position: 1}}, wantErr: true},
+		{name: "negative offset", req: PlayRequest{ContextURI: album, Offset: &PlayOffset{Position: -1}}, wantErr: true},
+		{name: "offset uri not in uris", req: PlayRequest{URIs: []lib.URI{track}, Offset: &PlayOffset{URI: episode}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr && !errors.Is(err, lib.Errors.InvalidRequest) {
+				t.Fatalf("err = %v, want %v", err, lib.Errors.InvalidRequest)
+			} else if !tt.wantErr && err != nil {
+				t.Fatalf("err = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestPlayRequestMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		req  PlayRequest
+		want string
+	}{
+		{name: "resume", req: PlayRequest{}, want: `{}`},
+		{name: "context", req: PlayRequest{ContextURI: "spotify:album:a"}, want: `{"context_uri":"spotify:album:a"}`},
+		{name: "uris with position", req: PlayRequest{URIs: []lib.URI{"spotify:track:t"}, Position: time.Millisecond * 1500}, want: `{"position_ms":1500,"uris":["spotify:track:t"]}`},
+		{name: "offset position", req: PlayRequest{ContextURI: "spotify:album:a", Offset: &PlayOffset{Position: 0}}, want: `{"context_uri":"spotify:album:a","offset":{"position":0}}`},
+		{name: "offset uri", req: PlayRequest{ContextURI: "spotify:album:a", Offset: &PlayOffset{Position: 3, URI: "spotify:track:t"}}, want: `{"context_uri":"spotify:album:a","offset":{"uri":"spotify:track:t"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func track(id string) lib.PlayableItem {
+	return lib.NewPlayableTrack(lib.TrackObject{ID: id, URI: "spotify:track:" + id})
+}
+
+func uris(items []lib.PlayableItem) []lib.URI {
+	res := []lib.URI{}
+	for _, item := range items {
+		res = append(res, item.URI())
+	}
+	return res
+}
+
+func TestDiffQueue(t *testing.T) {
+	a, b, c, d := track("a"), track("b"), track("c"), track("d")
+
+	tests := []struct {
+		name        string
+		prev, cur   lib.Queue
+		wantChanged bool
+		wantAdded   []lib.URI
+		wantRemoved []lib.URI
+	}{
+		{name: "unchanged", prev: lib.Queue{CurrentlyPlaying: a, Queue: []lib.PlayableItem{b, c}}, cur: lib.Queue{CurrentlyPlaying: a, Queue: []lib.PlayableItem{b, c}}, wantAdded: []lib.URI{}, wantRemoved: []lib.URI{}},
+		{name: "advanced", prev: lib.Queue{CurrentlyPlaying: a, Queue: []lib.PlayableItem{b, c}}, cur: lib.Queue{CurrentlyPlaying: b, Queue: []lib.PlayableItem{c, d}}, wantChanged: true, wantAdded: []lib.URI{d.URI()}, wantRemoved: []lib.URI{b.URI()}},
+		{name: "duplicates counted", prev: lib.Queue{CurrentlyPlaying: a, Queue: []lib.PlayableItem{b}}, cur: lib.Queue{CurrentlyPlaying: a, Queue: []lib.PlayableItem{b, b, c}}, wantAdded: []lib.URI{b.URI(), c.URI()}, wantRemoved: []lib.URI{}},
+		{name: "emptied", prev: lib.Queue{CurrentlyPlaying: a, Queue: []lib.PlayableItem{b, c}}, cur: lib.Queue{}, wantChanged: true, wantAdded: []lib.URI{}, wantRemoved: []lib.URI{b.URI(), c.URI()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := DiffQueue(tt.prev, tt.cur)
+			if diff.CurrentlyPlayingChanged != tt.wantChanged {
+				t.Errorf("CurrentlyPlayingChanged = %v, want %v", diff.CurrentlyPlayingChanged, tt.wantChanged)
+			}
+			if got := uris(diff.Added); !slices.Equal(got, tt.wantAdded) {
+				t.Errorf("Added = %v, want %v", got, tt.wantAdded)
+			}
+			if got := uris(diff.Removed); !slices.Equal(got, tt.wantRemoved) {
+				t.Errorf("Removed = %v, want %v", got, tt.wantRemoved)
+			}
+		})
+	}
+}
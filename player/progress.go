@@ -0,0 +1,86 @@
+package player
+
+import (
+	"sync"
+	"time"
+
+	"github.com/HandyGold75/gotify/lib"
+)
+
+// maxTimestampSkew bounds how far `Timestamp` may lie before receiving a state to be trusted as the moment `ProgressMs` was measured.
+//
+// Spotify sometimes reports the time of the last state change instead, which would make the progress run ahead.
+const maxTimestampSkew = time.Second
+
+// Progress interpolates the position of the current item from the last playback state, allowing smooth progress with infrequent polling.
+//
+// The zero value is ready to use and safe for concurrent use.
+type Progress struct {
+	mu    sync.Mutex
+	state lib.PlaybackState
+	ref   time.Time // Local time at which `ProgressMs` of state was measured.
+}
+
+// Update replaces the snapshot with state, which should be received just now.
+func (p *Progress) Update(state lib.PlaybackState) {
+	p.UpdateAt(state, time.Now())
+}
+
+// UpdateAt replaces the snapshot with state received at the local time received.
+//
+// `Timestamp` is used as measuring moment if it lies slightly before received, correcting for latency, it is ignored if it lies in the future (clock skew) or too far in the past.
+func (p *Progress) UpdateAt(state lib.PlaybackState, received time.Time) {
+	ref := received
+	if state.Timestamp > 0 {
+		if ts := time.UnixMilli(int64(state.Timestamp)); !ts.After(received) && received.Sub(ts) <= maxTimestampSkew {
+			ref = ts
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.state, p.ref = state, ref
+}
+
+// State returns the last snapshot.
+func (p *Progress) State() lib.PlaybackState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.state
+}
+
+// Position returns the interpolated position of the current item.
+func (p *Progress) Position() time.Duration {
+	return p.PositionAt(time.Now())
+}
+
+// PositionAt returns the interpolated position of the current item at the local time t.
+//
+// The position only advances while playing and is clamped between zero and the duration of the item.
+func (p *Progress) PositionAt(t time.Time) time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pos := time.Duration(p.state.ProgressMs) * time.Millisecond
+	if p.state.IsPlaying && !p.ref.IsZero() {
+		pos += t.Sub(p.ref)
+	}
+	if dur := p.state.Item.Duration(); dur > 0 {
+		pos = min(pos, dur)
+	}
+	return max(0, pos)
+}
+
+// Duration returns the duration of the current item, zero if unknown.
+func (p *Progress) Duration() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.state.Item.Duration()
+}
+
+// Fraction returns the interpolated position as fraction of the duration between 0 and 1, zero if the duration is unknown.
+func (p *Progress) Fraction() float64 {
+	dur := p.Duration()
+	if dur <= 0 {
+		return 0
+	}
+	return float64(p.Position()) / float64(dur)
+}
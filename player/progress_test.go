@@ -0,0 +1,46 @@
+package player
+
+import (
+	"testing"
+	"time"
+
+	"github.com/HandyGold75/gotify/lib"
+)
+
+func TestProgressPositionAt(t *testing.T) {
+	received := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	item := lib.NewPlayableTrack(lib.TrackObject{URI: "spotify:track:a", DurationMs: 10000})
+	ms := func(ms int) time.Duration { return time.Duration(ms) * time.Millisecond }
+
+	tests := []struct {
+		name  string
+		state lib.PlaybackState
+		after time.Duration // Time after received the position is interpolated at.
+		want  time.Duration
+	}{
+		{name: "playing", state: lib.PlaybackState{Item: item, IsPlaying: true, ProgressMs: 2000}, after: ms(1500), want: ms(3500)},
+		{name: "paused", state: lib.PlaybackState{Item: item, ProgressMs: 2000}, after: ms(1500), want: ms(2000)},
+		{name: "clamped to duration", state: lib.PlaybackState{Item: item, IsPlaying: true, ProgressMs: 9000}, after: ms(5000), want: ms(10000)},
+		{name: "clamped to zero", state: lib.PlaybackState{Item: item, IsPlaying: true}, after: -ms(500), want: 0},
+		{name: "without item", state: lib.PlaybackState{IsPlaying: true, ProgressMs: 2000}, after: ms(1000), want: ms(3000)},
+		{name: "timestamp corrects latency", state: lib.PlaybackState{Item: item, IsPlaying: true, ProgressMs: 2000, Timestamp: int(received.Add(-ms(300)).UnixMilli())}, after: ms(1000), want: ms(3300)},
+		{name: "timestamp too old", state: lib.PlaybackState{Item: item, IsPlaying: true, ProgressMs: 2000, Timestamp: int(received.Add(-time.Minute).UnixMilli())}, after: ms(1000), want: ms(3000)},
+		{name: "timestamp in future", state: lib.PlaybackState{Item: item, IsPlaying: true, ProgressMs: 2000, Timestamp: int(received.Add(ms(500)).UnixMilli())}, after: ms(1000), want: ms(3000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Progress{}
+			p.UpdateAt(tt.state, received)
+			if got := p.PositionAt(received.Add(tt.after)); got != tt.want {
+				t.Errorf("PositionAt = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProgressZeroValue(t *testing.T) {
+	p := Progress{}
+	if pos, dur, fract := p.Position(), p.Duration(), p.Fraction(); pos != 0 || dur != 0 || fract != 0 {
+		t.Errorf("zero value = %v, %v, %v, want zeros", pos, dur, fract)
+	}
+}
@@ -0,0 +1,187 @@
+package player
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/HandyGold75/gotify/lib"
+)
+
+type (
+	// Watcher polls `GetPlaybackState` and emits an `Event` for every change between successive states.
+	//
+	// The interval adapts to the playback, polling right after the current item should end and slowing down while nothing is playing.
+	Watcher struct {
+		Player      *Player
+		Interval    time.Duration     // Interval between polls while playing.
+		MinInterval time.Duration     // Lower bound for the interval, used near the end of an item.
+		MaxInterval time.Duration     // Interval between polls while paused or without active device.
+		OnEvent     func(event Event) // Called for every event, may be nil.
+		OnError     func(err error)   // Called for every failed poll, polling continues afterwards, may be nil.
+		Progress    *Progress         // Updated with every polled state for interpolating the position between polls, may be nil.
+	}
+
+	// Event is emitted by `Watcher` for every detected change, use a type switch on the types below to handle them.
+	Event interface {
+		State() lib.PlaybackState
+	}
+
+	// TrackChanged is emitted when the playing track or episode changed.
+	TrackChanged struct {
+		Prev, Current lib.PlayableItem
+		state         lib.PlaybackState
+	}
+	// PlaybackPaused is emitted when playback is paused or stopped.
+	PlaybackPaused struct {
+		Progress time.Duration
+		state    lib.PlaybackState
+	}
+	// PlaybackResumed is emitted when playback is started or resumed.
+	PlaybackResumed struct {
+		Progress time.Duration
+		state    lib.PlaybackState
+	}
+	// DeviceChanged is emitted when playback moved to another device, `Current` is empty if no device is active anymore.
+	DeviceChanged struct {
+		Prev, Current lib.Device
+		state         lib.PlaybackState
+	}
+	// VolumeChanged is emitted when the volume of the active device changed.
+	VolumeChanged struct {
+		Prev, Current int
+		state         lib.PlaybackState
+	}
+	// ShuffleChanged is emitted when shuffle is toggled.
+	ShuffleChanged struct {
+		Shuffle bool
+		state   lib.PlaybackState
+	}
+	// ContextChanged is emitted when the playing album, artist, playlist or show changed.
+	ContextChanged struct {
+		Prev, Current lib.ContextObject
+		state         lib.PlaybackState
+	}
+)
+
+func NewWatcher(player *Player) Watcher {
+	return Watcher{Player: player, Interval: time.Second * 5, MinInterval: time.Second, MaxInterval: time.Second * 15, OnEvent: nil, OnError: nil, Progress: &Progress{}}
+}
+
+// State returns the playback state the change was detected in.
+func (e TrackChanged) State() lib.PlaybackState { return e.state }
+
+// State returns the playback state the change was detected in.
+func (e PlaybackPaused) State() lib.PlaybackState { return e.state }
+
+// State returns the playback state the change was detected in.
+func (e PlaybackResumed) State() lib.PlaybackState { return e.state }
+
+// State returns the playback state the change was detected in.
+func (e DeviceChanged) State() lib.PlaybackState { return e.state }
+
+// State returns the playback state the change was detected in.
+func (e VolumeChanged) State() lib.PlaybackState { return e.state }
+
+// State returns the playback state the change was detected in.
+func (e ShuffleChanged) State() lib.PlaybackState { return e.state }
+
+// State returns the playback state the change was detected in.
+func (e ContextChanged) State() lib.PlaybackState { return e.state }
+
+// DiffState returns the events for the changes from prev to the state s, in the order device, context, track, shuffle, volume and playback.
+func DiffState(prev, s lib.PlaybackState) []Event {
+	events := []Event{}
+	if s.Device.ID != prev.Device.ID {
+		events = append(events, DeviceChanged{Prev: prev.Device, Current: s.Device, state: s})
+	}
+	if s.Context.URI != prev.Context.URI {
+		events = append(events, ContextChanged{Prev: prev.Context, Current: s.Context, state: s})
+	}
+	if s.Item.URI() != prev.Item.URI() {
+		events = append(events, TrackChanged{Prev: prev.Item, Current: s.Item, state: s})
+	}
+	if s.ShuffleState != prev.ShuffleState {
+		events = append(events, ShuffleChanged{Shuffle: s.ShuffleState, state: s})
+	}
+	if s.Device.ID == prev.Device.ID && s.Device.VolumePercent != prev.Device.VolumePercent {
+		events = append(events, VolumeChanged{Prev: prev.Device.VolumePercent, Current: s.Device.VolumePercent, state: s})
+	}
+	if s.IsPlaying && !prev.IsPlaying {
+		events = append(events, PlaybackResumed{Progress: time.Duration(s.ProgressMs) * time.Millisecond, state: s})
+	} else if !s.IsPlaying && prev.IsPlaying {
+		events = append(events, PlaybackPaused{Progress: time.Duration(s.ProgressMs) * time.Millisecond, state: s})
+	}
+	return events
+}
+
+// Scopes: `ScopeUserReadPlaybackState`
+//
+// Run polls until ctx is done, calling `OnEvent` for every change and `OnError` for every failed poll.
+//
+// The first poll only emits events for the difference with an empty state, Ex: `TrackChanged` for the playing track.
+func (w *Watcher) Run(ctx context.Context) error {
+	return w.run(ctx, func(event Event) {
+		if w.OnEvent != nil {
+			w.OnEvent(event)
+		}
+	})
+}
+
+// Scopes: `ScopeUserReadPlaybackState`
+//
+// Watch polls in the background until ctx is done, sending every change to the returned channel which is closed afterwards.
+//
+// `OnEvent` and `OnError` are called as well, events are not dropped so the channel should be drained.
+func (w *Watcher) Watch(ctx context.Context) <-chan Event {
+	events := make(chan Event, 16)
+	go func() {
+		defer close(events)
+		_ = w.run(ctx, func(event Event) {
+			if w.OnEvent != nil {
+				w.OnEvent(event)
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+			}
+		})
+	}()
+	return events
+}
+
+func (w *Watcher) run(ctx context.Context, emit func(event Event)) error {
+	prev := lib.PlaybackState{}
+	for {
+		state, err := w.Player.GetPlaybackStateCtx(ctx)
+		if errors.Is(err, lib.Errors.NoContent) {
+			state, err = lib.PlaybackState{}, nil
+		}
+		if err != nil && ctx.Err() == nil && w.OnError != nil {
+			w.OnError(err)
+		} else if err == nil {
+			if w.Progress != nil {
+				w.Progress.Update(state)
+			}
+			for _, event := range DiffState(prev, state) {
+				emit(event)
+			}
+			prev = state
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(w.interval(prev)):
+		}
+	}
+}
+
+// interval returns the delay until the next poll, polling shortly after the current item should end while playing.
+func (w *Watcher) interval(state lib.PlaybackState) time.Duration {
+	if !state.IsPlaying {
+		return max(w.MinInterval, w.MaxInterval)
+	}
+	remaining := state.Item.Duration() - time.Duration(state.ProgressMs)*time.Millisecond + time.Millisecond*500
+	return max(w.MinInterval, min(w.Interval, remaining))
+}
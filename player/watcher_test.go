@@ -0,0 +1,74 @@
+package player
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/HandyGold75/gotify/lib"
+)
+
+func TestDiffState(t *testing.T) {
+	speaker, phone := lib.Device{ID: "speaker", VolumePercent: 50}, lib.Device{ID: "phone", VolumePercent: 20}
+	album := lib.ContextObject{Type: "album", URI: "spotify:album:a"}
+	base := lib.PlaybackState{Device: speaker, Context: album, Item: track("a"), IsPlaying: true, ProgressMs: 1000}
+
+	with := func(fn func(state *lib.PlaybackState)) lib.PlaybackState {
+		state := base
+		fn(&state)
+		return state
+	}
+
+	tests := []struct {
+		name      string
+		prev, cur lib.PlaybackState
+		want      []string
+	}{
+		{name: "unchanged", prev: base, cur: with(func(s *lib.PlaybackState) { s.ProgressMs = 5000 }), want: []string{}},
+		{name: "first poll", prev: lib.PlaybackState{}, cur: base, want: []string{"DeviceChanged", "ContextChanged", "TrackChanged", "PlaybackResumed"}},
+		{name: "stopped", prev: base, cur: lib.PlaybackState{}, want: []string{"DeviceChanged", "ContextChanged", "TrackChanged", "PlaybackPaused"}},
+		{name: "next track", prev: base, cur: with(func(s *lib.PlaybackState) { s.Item = track("b") }), want: []string{"TrackChanged"}},
+		{name: "paused", prev: base, cur: with(func(s *lib.PlaybackState) { s.IsPlaying = false }), want: []string{"PlaybackPaused"}},
+		{name: "shuffle", prev: base, cur: with(func(s *lib.PlaybackState) { s.ShuffleState = true }), want: []string{"ShuffleChanged"}},
+		{name: "volume", prev: base, cur: with(func(s *lib.PlaybackState) { s.Device.VolumePercent = 80 }), want: []string{"VolumeChanged"}},
+		{name: "device without volume event", prev: base, cur: with(func(s *lib.PlaybackState) { s.Device = phone }), want: []string{"DeviceChanged"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, event := range DiffState(tt.prev, tt.cur) {
+				got = append(got, fmt.Sprintf("%T", event)[len("player."):])
+				if event.State().Item.URI() != tt.cur.Item.URI() {
+					t.Errorf("%T state is not the current state", event)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffStateValues(t *testing.T) {
+	prev := lib.PlaybackState{Device: lib.Device{ID: "d", VolumePercent: 10}, Item: track("a"), IsPlaying: true}
+	cur := lib.PlaybackState{Device: lib.Device{ID: "d", VolumePercent: 30}, Item: track("b"), ProgressMs: 2500}
+
+	for _, event := range DiffState(prev, cur) {
+		switch event := event.(type) {
+		case TrackChanged:
+			if event.Prev.URI() != prev.Item.URI() || event.Current.URI() != cur.Item.URI() {
+				t.Errorf("TrackChanged = %v -> %v", event.Prev.URI(), event.Current.URI())
+			}
+		case VolumeChanged:
+			if event.Prev != 10 || event.Current != 30 {
+				t.Errorf("VolumeChanged = %d -> %d", event.Prev, event.Current)
+			}
+		case PlaybackPaused:
+			if event.Progress.Milliseconds() != 2500 {
+				t.Errorf("PlaybackPaused.Progress = %v", event.Progress)
+			}
+		default:
+			t.Errorf("unexpected event %T", event)
+		}
+	}
+}
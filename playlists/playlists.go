@@ -1,53 +1,110 @@
 package playlists
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
+	"iter"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/HandyGold75/gotify/lib"
 )
 
-// TODO: All responses
+type (
+	Playlists struct {
+		Send   func(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)
+		Market string // An ISO 3166-1 alpha-2 country code, https://en.wikipedia.org/wiki/ISO_3166-1_alpha-2
+	}
+)
 
-type Playlists struct {
-	Send   func(method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)
-	Market string // An ISO 3166-1 alpha-2 country code, https://en.wikipedia.org/wiki/ISO_3166-1_alpha-2
+func New(send func(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)) Playlists {
+	return Playlists{Send: send}
 }
 
-func New(send func(method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)) Playlists {
-	return Playlists{Send: send}
+func (s *Playlists) GetPlaylist(id string, fields []string) (lib.PlaylistObject, error) {
+	return s.GetPlaylistCtx(context.Background(), id, fields)
 }
 
-func (s *Playlists) GetPlaylist(id string, fields []string) error {
-	_, err := s.Send(lib.GET, "playlists/"+id+"", [][2]string{{"market", s.Market}, {"fields", strings.Join(fields, ",")}}, []byte{})
-	return err
+func (s *Playlists) GetPlaylistCtx(ctx context.Context, id string, fields []string) (lib.PlaylistObject, error) {
+	res, err := s.Send(ctx, lib.GET, "playlists/"+id+"", [][2]string{{"market", s.Market}, {"fields", strings.Join(fields, ",")}, {"additional_types", "track,episode"}}, []byte{})
+	if err != nil {
+		return lib.PlaylistObject{}, err
+	}
+	data := lib.PlaylistObject{}
+	err = json.Unmarshal(res, &data)
+	return data, err
 }
 
 // Scopes: `ScopePlaylistModifyPublic`, `ScopePlaylistModifyPrivate`
 func (s *Playlists) ChangePlaylistDetails(id, name string, public, collaborative bool, description string) error {
+	return s.ChangePlaylistDetailsCtx(context.Background(), id, name, public, collaborative, description)
+}
+
+// Scopes: `ScopePlaylistModifyPublic`, `ScopePlaylistModifyPrivate`
+func (s *Playlists) ChangePlaylistDetailsCtx(ctx context.Context, id, name string, public, collaborative bool, description string) error {
 	body, err := json.Marshal(map[string]any{"name": name, "public": public, "collaborative": collaborative, "description": description})
 	if err != nil {
 		return err
 	}
-	_, err = s.Send(lib.PUT, "playlists/"+id+"", [][2]string{}, body)
+	_, err = s.Send(ctx, lib.PUT, "playlists/"+id+"", [][2]string{}, body)
 	return err
 }
 
 // Scopes: `ScopePlaylistReadPrivate`
-func (s *Playlists) GetPlaylistItems(id string, fields []string, limit, offset int) error {
-	_, err := s.Send(lib.GET, "playlists/"+id+"/tracks", [][2]string{{"market", s.Market}, {"fields", strings.Join(fields, ",")}, {"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
-	return err
+func (s *Playlists) GetPlaylistItems(id string, fields []string, limit, offset int) (lib.Paging[lib.PlaylistTrackObject], error) {
+	return s.GetPlaylistItemsCtx(context.Background(), id, fields, limit, offset)
+}
+
+// Scopes: `ScopePlaylistReadPrivate`
+func (s *Playlists) GetPlaylistItemsCtx(ctx context.Context, id string, fields []string, limit, offset int) (lib.Paging[lib.PlaylistTrackObject], error) {
+	res, err := s.Send(ctx, lib.GET, "playlists/"+id+"/tracks", [][2]string{{"market", s.Market}, {"fields", strings.Join(fields, ",")}, {"additional_types", "track,episode"}, {"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
+	if err != nil {
+		return lib.Paging[lib.PlaylistTrackObject]{}, err
+	}
+	data := lib.Paging[lib.PlaylistTrackObject]{}
+	err = json.Unmarshal(res, &data)
+	return data, err
+}
+
+// Scopes: `ScopePlaylistReadPrivate`
+//
+// A non-empty fields filter is extended with "next" and "total", which are required to fetch the next pages.
+func (s *Playlists) AllPlaylistItems(id string, fields []string) iter.Seq2[lib.PlaylistTrackObject, error] {
+	return s.AllPlaylistItemsCtx(context.Background(), id, fields)
+}
+
+// Scopes: `ScopePlaylistReadPrivate`
+//
+// A non-empty fields filter is extended with "next" and "total", which are required to fetch the next pages.
+func (s *Playlists) AllPlaylistItemsCtx(ctx context.Context, id string, fields []string) iter.Seq2[lib.PlaylistTrackObject, error] {
+	if len(fields) > 0 {
+		fields = slices.Clone(fields)
+		for _, field := range []string{"next", "total"} {
+			if !slices.Contains(fields, field) {
+				fields = append(fields, field)
+			}
+		}
+	}
+	return lib.Paginate(func(offset int) ([]lib.PlaylistTrackObject, lib.ItemsHeaders, error) {
+		data, err := s.GetPlaylistItemsCtx(ctx, id, fields, 50, offset)
+		return data.Items, data.ItemsHeaders, err
+	})
 }
 
 // Scopes: `ScopePlaylistModifyPublic`, `ScopePlaylistModifyPrivate`
 func (s *Playlists) UpdatePlaylistItemsReoder(id string, start, before, length int, snapshot string) (string, error) {
+	return s.UpdatePlaylistItemsReoderCtx(context.Background(), id, start, before, length, snapshot)
+}
+
+// Scopes: `ScopePlaylistModifyPublic`, `ScopePlaylistModifyPrivate`
+func (s *Playlists) UpdatePlaylistItemsReoderCtx(ctx context.Context, id string, start, before, length int, snapshot string) (string, error) {
 	body, err := json.Marshal(map[string]any{"range_start": start, "insert_before": before, "range_length": length, "snapshot_id": snapshot})
 	if err != nil {
 		return "", err
 	}
-	res, err := s.Send(lib.PUT, "playlists/"+id+"/tracks", [][2]string{}, body)
+	res, err := s.Send(ctx, lib.PUT, "playlists/"+id+"/tracks", [][2]string{}, body)
 	if err != nil {
 		return "", err
 	}
@@ -59,95 +116,208 @@ func (s *Playlists) UpdatePlaylistItemsReoder(id string, start, before, length i
 }
 
 // Scopes: `ScopePlaylistModifyPublic`, `ScopePlaylistModifyPrivate`
+//
+// More than 100 items are sent in sequential batches of 100, the snapshot of the last batch is returned.
 func (s *Playlists) UpdatePlaylistItemsReplace(id string, uris []lib.URI) (string, error) {
-	body, err := json.Marshal(map[string]any{"uris": uris})
+	return s.UpdatePlaylistItemsReplaceCtx(context.Background(), id, uris)
+}
+
+// Scopes: `ScopePlaylistModifyPublic`, `ScopePlaylistModifyPrivate`
+//
+// More than 100 items are sent in sequential batches of 100, the snapshot of the last batch is returned.
+func (s *Playlists) UpdatePlaylistItemsReplaceCtx(ctx context.Context, id string, uris []lib.URI) (string, error) {
+	first := uris[:min(100, len(uris))]
+	body, err := json.Marshal(map[string]any{"uris": first})
 	if err != nil {
 		return "", err
 	}
-	res, err := s.Send(lib.PUT, "playlists/"+id+"/tracks", [][2]string{}, body)
+	res, err := s.Send(ctx, lib.PUT, "playlists/"+id+"/tracks", [][2]string{}, body)
 	if err != nil {
 		return "", err
 	}
 	data := struct {
 		SnapshotID string `json:"snapshot_id"`
 	}{}
-	err = json.Unmarshal(res, &data)
-	return data.SnapshotID, err
+	if err := json.Unmarshal(res, &data); err != nil || len(uris) <= len(first) {
+		return data.SnapshotID, err
+	}
+	return s.AddItemsToPlaylistCtx(ctx, id, uris[len(first):], len(first))
 }
 
 // Scopes: `ScopePlaylistModifyPublic`, `ScopePlaylistModifyPrivate`
+//
+// More than 100 items are sent in sequential batches of 100, the snapshot of the last batch is returned.
 func (s *Playlists) AddItemsToPlaylist(id string, uris []lib.URI, position int) (string, error) {
-	body, err := json.Marshal(map[string]any{"uris": uris, "position": max(0, position)})
-	if err != nil {
-		return "", err
-	}
-	res, err := s.Send(lib.POST, "playlists/"+id+"/tracks", [][2]string{}, body)
-	if err != nil {
-		return "", err
+	return s.AddItemsToPlaylistCtx(context.Background(), id, uris, position)
+}
+
+// Scopes: `ScopePlaylistModifyPublic`, `ScopePlaylistModifyPrivate`
+//
+// More than 100 items are sent in sequential batches of 100, the snapshot of the last batch is returned.
+func (s *Playlists) AddItemsToPlaylistCtx(ctx context.Context, id string, uris []lib.URI, position int) (string, error) {
+	snapshot := ""
+	for i, batch := range slices.Collect(slices.Chunk(uris, 100)) {
+		body, err := json.Marshal(map[string]any{"uris": batch, "position": max(0, position) + i*100})
+		if err != nil {
+			return "", err
+		}
+		res, err := s.Send(ctx, lib.POST, "playlists/"+id+"/tracks", [][2]string{}, body)
+		if err != nil {
+			return "", err
+		}
+		data := struct {
+			SnapshotID string `json:"snapshot_id"`
+		}{}
+		if err := json.Unmarshal(res, &data); err != nil {
+			return "", err
+		}
+		snapshot = data.SnapshotID
 	}
-	data := struct {
-		SnapshotID string `json:"snapshot_id"`
-	}{}
-	err = json.Unmarshal(res, &data)
-	return data.SnapshotID, err
+	return snapshot, nil
 }
 
 // Scopes: `ScopePlaylistModifyPublic`, `ScopePlaylistModifyPrivate`
+//
+// More than 100 items are sent in sequential batches of 100, the snapshot of the last batch is returned.
 func (s *Playlists) RemovePlaylistItems(id string, tracks []lib.URI, snapshot string) (string, error) {
-	bodyTracks := []map[string]lib.URI{}
-	for _, track := range tracks {
-		bodyTracks = append(bodyTracks, map[string]lib.URI{"uri": track})
+	return s.RemovePlaylistItemsCtx(context.Background(), id, tracks, snapshot)
+}
+
+// Scopes: `ScopePlaylistModifyPublic`, `ScopePlaylistModifyPrivate`
+//
+// More than 100 items are sent in sequential batches of 100, the snapshot of the last batch is returned.
+func (s *Playlists) RemovePlaylistItemsCtx(ctx context.Context, id string, tracks []lib.URI, snapshot string) (string, error) {
+	for batch := range slices.Chunk(tracks, 100) {
+		bodyTracks := []map[string]lib.URI{}
+		for _, track := range batch {
+			bodyTracks = append(bodyTracks, map[string]lib.URI{"uri": track})
+		}
+		body, err := json.Marshal(map[string]any{"tracks": bodyTracks, "snapshot_id": snapshot})
+		if err != nil {
+			return "", err
+		}
+		res, err := s.Send(ctx, lib.DELETE, "playlists/"+id+"/tracks", [][2]string{}, body)
+		if err != nil {
+			return "", err
+		}
+		data := struct {
+			SnapshotID string `json:"snapshot_id"`
+		}{}
+		if err := json.Unmarshal(res, &data); err != nil {
+			return "", err
+		}
+		snapshot = data.SnapshotID
 	}
-	body, err := json.Marshal(map[string]any{"tracks": bodyTracks, "snapshot_id": snapshot})
+	return snapshot, nil
+}
+
+// Scopes: `ScopePlaylistReadPrivate`
+func (s *Playlists) GetCurrentUsersPlaylists(limit, offset int) (lib.Paging[lib.PlaylistSimpleObject], error) {
+	return s.GetCurrentUsersPlaylistsCtx(context.Background(), limit, offset)
+}
+
+// Scopes: `ScopePlaylistReadPrivate`
+func (s *Playlists) GetCurrentUsersPlaylistsCtx(ctx context.Context, limit, offset int) (lib.Paging[lib.PlaylistSimpleObject], error) {
+	res, err := s.Send(ctx, lib.GET, "me/playlists", [][2]string{{"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
 	if err != nil {
-		return "", err
+		return lib.Paging[lib.PlaylistSimpleObject]{}, err
 	}
-	res, err := s.Send(lib.DELETE, "playlists/"+id+"/tracks", [][2]string{}, body)
+	data := lib.Paging[lib.PlaylistSimpleObject]{}
+	err = json.Unmarshal(res, &data)
+	return data, err
+}
+
+// Scopes: `ScopePlaylistReadPrivate`
+func (s *Playlists) AllCurrentUsersPlaylists() iter.Seq2[lib.PlaylistSimpleObject, error] {
+	return s.AllCurrentUsersPlaylistsCtx(context.Background())
+}
+
+// Scopes: `ScopePlaylistReadPrivate`
+func (s *Playlists) AllCurrentUsersPlaylistsCtx(ctx context.Context) iter.Seq2[lib.PlaylistSimpleObject, error] {
+	return lib.Paginate(func(offset int) ([]lib.PlaylistSimpleObject, lib.ItemsHeaders, error) {
+		data, err := s.GetCurrentUsersPlaylistsCtx(ctx, 50, offset)
+		return data.Items, data.ItemsHeaders, err
+	})
+}
+
+// Scopes: `ScopePlaylistReadPrivate`, `ScopePlaylistReadCollaborative`
+func (s *Playlists) GetUsersPlaylists(id string, limit, offset int) (lib.Paging[lib.PlaylistSimpleObject], error) {
+	return s.GetUsersPlaylistsCtx(context.Background(), id, limit, offset)
+}
+
+// Scopes: `ScopePlaylistReadPrivate`, `ScopePlaylistReadCollaborative`
+func (s *Playlists) GetUsersPlaylistsCtx(ctx context.Context, id string, limit, offset int) (lib.Paging[lib.PlaylistSimpleObject], error) {
+	res, err := s.Send(ctx, lib.GET, "users/"+id+"/playlists", [][2]string{{"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
 	if err != nil {
-		return "", err
+		return lib.Paging[lib.PlaylistSimpleObject]{}, err
 	}
-	data := struct {
-		SnapshotID string `json:"snapshot_id"`
-	}{}
+	data := lib.Paging[lib.PlaylistSimpleObject]{}
 	err = json.Unmarshal(res, &data)
-	return data.SnapshotID, err
+	return data, err
 }
 
-// Scopes: `ScopePlaylistReadPrivate`
-func (s *Playlists) GetCurrentUsersPlaylists(limit, offset int) error {
-	_, err := s.Send(lib.GET, "me/playlists", [][2]string{{"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
-	return err
+// Scopes: `ScopePlaylistReadPrivate`, `ScopePlaylistReadCollaborative`
+func (s *Playlists) AllUsersPlaylists(id string) iter.Seq2[lib.PlaylistSimpleObject, error] {
+	return s.AllUsersPlaylistsCtx(context.Background(), id)
 }
 
 // Scopes: `ScopePlaylistReadPrivate`, `ScopePlaylistReadCollaborative`
-func (s *Playlists) GetUsersPlaylists(id string, limit, offset int) error {
-	_, err := s.Send(lib.GET, "users/"+id+"/playlists", [][2]string{{"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
-	return err
+func (s *Playlists) AllUsersPlaylistsCtx(ctx context.Context, id string) iter.Seq2[lib.PlaylistSimpleObject, error] {
+	return lib.Paginate(func(offset int) ([]lib.PlaylistSimpleObject, lib.ItemsHeaders, error) {
+		data, err := s.GetUsersPlaylistsCtx(ctx, id, 50, offset)
+		return data.Items, data.ItemsHeaders, err
+	})
 }
 
 // Scopes: `ScopePlaylistModifyPublic`, `ScopePlaylistModifyPrivate`
-func (s *Playlists) CreatePlaylist(id, name string, public, collaborative bool, description string) error {
+func (s *Playlists) CreatePlaylist(id, name string, public, collaborative bool, description string) (lib.PlaylistObject, error) {
+	return s.CreatePlaylistCtx(context.Background(), id, name, public, collaborative, description)
+}
+
+// Scopes: `ScopePlaylistModifyPublic`, `ScopePlaylistModifyPrivate`
+func (s *Playlists) CreatePlaylistCtx(ctx context.Context, id, name string, public, collaborative bool, description string) (lib.PlaylistObject, error) {
 	body, err := json.Marshal(map[string]any{"name": name, "public": public, "collaborative": collaborative, "description": description})
 	if err != nil {
-		return err
+		return lib.PlaylistObject{}, err
 	}
-	_, err = s.Send(lib.POST, "users/"+id+"/playlists", [][2]string{}, body)
-	return err
+	res, err := s.Send(ctx, lib.POST, "users/"+id+"/playlists", [][2]string{}, body)
+	if err != nil {
+		return lib.PlaylistObject{}, err
+	}
+	data := lib.PlaylistObject{}
+	err = json.Unmarshal(res, &data)
+	return data, err
 }
 
-func (s *Playlists) GetPlaylistCoverImage(id string) error {
-	_, err := s.Send(lib.GET, "playlists/"+id+"/images", [][2]string{}, []byte{})
-	return err
+func (s *Playlists) GetPlaylistCoverImage(id string) ([]lib.Image, error) {
+	return s.GetPlaylistCoverImageCtx(context.Background(), id)
+}
+
+func (s *Playlists) GetPlaylistCoverImageCtx(ctx context.Context, id string) ([]lib.Image, error) {
+	res, err := s.Send(ctx, lib.GET, "playlists/"+id+"/images", [][2]string{}, []byte{})
+	if err != nil {
+		return []lib.Image{}, err
+	}
+	data := []lib.Image{}
+	err = json.Unmarshal(res, &data)
+	return data, err
 }
 
 // Scopes: `ScopeUgcImageUpload`, `ScopePlaylistModifyPublic`, `ScopePlaylistModifyPrivate`
 //
 // `img` should be Base64 encoded JPEG image data, maximum payload size is 256 KB.
 func (s *Playlists) AddCustomPlaylistCoverImage(id string, img string) error {
+	return s.AddCustomPlaylistCoverImageCtx(context.Background(), id, img)
+}
+
+// Scopes: `ScopeUgcImageUpload`, `ScopePlaylistModifyPublic`, `ScopePlaylistModifyPrivate`
+//
+// `img` should be Base64 encoded JPEG image data, maximum payload size is 256 KB.
+func (s *Playlists) AddCustomPlaylistCoverImageCtx(ctx context.Context, id string, img string) error {
 	body, err := base64.StdEncoding.DecodeString(img)
 	if err != nil {
 		return err
 	}
-	_, err = s.Send(lib.PUT, "playlists/"+id+"/images", [][2]string{}, body)
+	_, err = s.Send(ctx, lib.PUT, "playlists/"+id+"/images", [][2]string{}, body)
 	return err
 }
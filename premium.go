@@ -0,0 +1,82 @@
+package gotify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/HandyGold75/gotify/lib"
+)
+
+// productRetryDelay is how long a failure to fetch the product is cached, so premium checks do not refetch it for every request.
+const productRetryDelay = time.Minute
+
+// productFailure holds the last failure to fetch the product.
+type productFailure struct {
+	err error
+	at  time.Time
+}
+
+// IsPremium reports whether the current user has a premium account, the product is fetched once and cached until authenticating again.
+//
+// Spotify only reports the product with `ScopeUserReadPrivate`, without it false is returned.
+func (gp *GotifyPlayer) IsPremium() (bool, error) {
+	return gp.IsPremiumCtx(context.Background())
+}
+
+// IsPremiumCtx reports whether the current user has a premium account, the product is fetched once and cached until authenticating again.
+//
+// Spotify only reports the product with `ScopeUserReadPrivate`, without it false is returned.
+func (gp *GotifyPlayer) IsPremiumCtx(ctx context.Context) (bool, error) {
+	product, err := gp.productCtx(ctx)
+	return product == "premium", err
+}
+
+// productCtx returns the cached product of the current user, fetching it if not cached yet.
+//
+// A failed fetch is returned again without fetching for `productRetryDelay`, except when it failed by ctx.
+func (gp *GotifyPlayer) productCtx(ctx context.Context) (string, error) {
+	if product := gp.product.Load(); product != nil {
+		return *product, nil
+	} else if failure := gp.productErr.Load(); failure != nil && time.Since(failure.at) < productRetryDelay {
+		return "", failure.err
+	}
+	profile, err := gp.Users.GetCurrentUsersProfileCtx(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			gp.productErr.Store(&productFailure{err: err, at: time.Now()})
+		}
+		return "", err
+	}
+	gp.product.Store(&profile.Product)
+	return profile.Product, nil
+}
+
+// premiumRoute reports whether the route controls playback, which Spotify only allows for premium accounts.
+func premiumRoute(method lib.HTTPMethod, action string) bool {
+	action = strings.Trim(action, "/")
+	return (method == lib.PUT || method == lib.POST) && (action == "player" || strings.HasPrefix(action, "player/"))
+}
+
+// checkPremium returns `lib.Errors.PremiumRequired` for premium only routes if the user is known to not have a premium account.
+//
+// If the product can not be determined the request is allowed, leaving the decision to Spotify.
+func (gp *GotifyPlayer) checkPremium(ctx context.Context, method lib.HTTPMethod, action string) error {
+	if !premiumRoute(method, action) {
+		return nil
+	}
+	if product, err := gp.productCtx(ctx); err == nil && product != "" && product != "premium" {
+		return fmt.Errorf("%w: %s %s", lib.Errors.PremiumRequired, method, action)
+	}
+	return nil
+}
+
+// notePremium caches a free product when Spotify refuses a request for requiring premium.
+func (gp *GotifyPlayer) notePremium(err error) {
+	if errors.Is(err, lib.Errors.PremiumRequired) {
+		product := "free"
+		gp.product.Store(&product)
+	}
+}
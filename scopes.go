@@ -0,0 +1,168 @@
+package gotify
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/HandyGold75/gotify/lib"
+	"golang.org/x/oauth2"
+)
+
+type (
+	// MissingScopeError is returned by `Send` when the token was not granted the scopes a route requires, the request is not sent.
+	MissingScopeError struct {
+		Scopes []string       // Any of these scopes would satisfy the route.
+		Method lib.HTTPMethod // Method of the refused request.
+		Path   string         // Path of the refused request, relative to the API base URL.
+	}
+
+	// scopeRoute lists the scopes required by requests matching method and path, every group requires any of its scopes.
+	scopeRoute struct {
+		methods []lib.HTTPMethod
+		path    string // Segments are matched literally, `*` matches any single segment.
+		groups  [][]scope
+	}
+)
+
+// scopeRoutes only lists routes that can not succeed without the scopes, optional scopes (Ex: `ScopeUserReadPrivate` for "me") are left out.
+var scopeRoutes = []scopeRoute{
+	{[]lib.HTTPMethod{lib.GET}, "me/albums", [][]scope{{ScopeUserLibraryRead}}},
+	{[]lib.HTTPMethod{lib.GET}, "me/albums/contains", [][]scope{{ScopeUserLibraryRead}}},
+	{[]lib.HTTPMethod{lib.PUT, lib.DELETE}, "me/albums", [][]scope{{ScopeUserLibraryModify}}},
+	{[]lib.HTTPMethod{lib.GET}, "me/audiobooks", [][]scope{{ScopeUserLibraryRead}}},
+	{[]lib.HTTPMethod{lib.GET}, "me/audiobooks/contains", [][]scope{{ScopeUserLibraryRead}}},
+	{[]lib.HTTPMethod{lib.PUT, lib.DELETE}, "me/audiobooks", [][]scope{{ScopeUserLibraryModify}}},
+	{[]lib.HTTPMethod{lib.GET}, "me/episodes", [][]scope{{ScopeUserLibraryRead}}},
+	{[]lib.HTTPMethod{lib.GET}, "me/episodes/contains", [][]scope{{ScopeUserLibraryRead}}},
+	{[]lib.HTTPMethod{lib.PUT, lib.DELETE}, "me/episodes", [][]scope{{ScopeUserLibraryModify}}},
+	{[]lib.HTTPMethod{lib.GET}, "me/shows", [][]scope{{ScopeUserLibraryRead}}},
+	{[]lib.HTTPMethod{lib.GET}, "me/shows/contains", [][]scope{{ScopeUserLibraryRead}}},
+	{[]lib.HTTPMethod{lib.PUT, lib.DELETE}, "me/shows", [][]scope{{ScopeUserLibraryModify}}},
+	{[]lib.HTTPMethod{lib.GET}, "me/tracks", [][]scope{{ScopeUserLibraryRead}}},
+	{[]lib.HTTPMethod{lib.GET}, "me/tracks/contains", [][]scope{{ScopeUserLibraryRead}}},
+	{[]lib.HTTPMethod{lib.PUT, lib.DELETE}, "me/tracks", [][]scope{{ScopeUserLibraryModify}}},
+
+	{[]lib.HTTPMethod{lib.GET}, "me/top/*", [][]scope{{ScopeUserTopRead}}},
+	{[]lib.HTTPMethod{lib.GET}, "me/following", [][]scope{{ScopeUserFollowRead}}},
+	{[]lib.HTTPMethod{lib.GET}, "me/following/contains", [][]scope{{ScopeUserFollowRead}}},
+	{[]lib.HTTPMethod{lib.PUT, lib.DELETE}, "me/following", [][]scope{{ScopeUserFollowModify}}},
+
+	{[]lib.HTTPMethod{lib.PUT}, "playlists/*", [][]scope{{ScopePlaylistModifyPublic, ScopePlaylistModifyPrivate}}},
+	{[]lib.HTTPMethod{lib.PUT, lib.POST, lib.DELETE}, "playlists/*/tracks", [][]scope{{ScopePlaylistModifyPublic, ScopePlaylistModifyPrivate}}},
+	{[]lib.HTTPMethod{lib.PUT, lib.DELETE}, "playlists/*/followers", [][]scope{{ScopePlaylistModifyPublic, ScopePlaylistModifyPrivate}}},
+	{[]lib.HTTPMethod{lib.PUT}, "playlists/*/images", [][]scope{{ScopeUgcImageUpload}, {ScopePlaylistModifyPublic, ScopePlaylistModifyPrivate}}},
+	{[]lib.HTTPMethod{lib.POST}, "users/*/playlists", [][]scope{{ScopePlaylistModifyPublic, ScopePlaylistModifyPrivate}}},
+
+	{[]lib.HTTPMethod{lib.GET}, "player", [][]scope{{ScopeUserReadPlaybackState}}},
+	{[]lib.HTTPMethod{lib.GET}, "player/devices", [][]scope{{ScopeUserReadPlaybackState}}},
+	{[]lib.HTTPMethod{lib.GET}, "player/currently-playing", [][]scope{{ScopeUserReadCurrentlyPlaying, ScopeUserReadPlaybackState}}},
+	{[]lib.HTTPMethod{lib.GET}, "player/queue", [][]scope{{ScopeUserReadCurrentlyPlaying, ScopeUserReadPlaybackState}}},
+	{[]lib.HTTPMethod{lib.GET}, "player/recently-played", [][]scope{{ScopeUserReadRecentlyPlayed}}},
+	{[]lib.HTTPMethod{lib.PUT, lib.POST}, "player", [][]scope{{ScopeUserModifyPlaybackState}}},
+	{[]lib.HTTPMethod{lib.PUT, lib.POST}, "player/*", [][]scope{{ScopeUserModifyPlaybackState}}},
+}
+
+func (e *MissingScopeError) Error() string {
+	return "missing scope " + strings.Join(e.Scopes, " or ") + " for " + string(e.Method) + " " + e.Path
+}
+
+func (e *MissingScopeError) Is(target error) bool {
+	return target == lib.Errors.MissingScope
+}
+
+func (r scopeRoute) match(method lib.HTTPMethod, action string) bool {
+	if !slices.Contains(r.methods, method) {
+		return false
+	}
+	segs, pattern := strings.Split(strings.Trim(action, "/"), "/"), strings.Split(r.path, "/")
+	if len(segs) != len(pattern) {
+		return false
+	}
+	for i, seg := range pattern {
+		if seg != "*" && seg != segs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// tokenScopes returns the scopes granted to token, ok is false if the token does not report its scopes.
+func tokenScopes(token *oauth2.Token) (scps []scope, ok bool) {
+	raw, ok := token.Extra("scope").(string)
+	if !ok {
+		return nil, false
+	}
+	scps = []scope{}
+	for _, scp := range strings.Fields(raw) {
+		scps = append(scps, scope(scp))
+	}
+	return scps, true
+}
+
+// GrantedScopes returns the scopes granted to the current token, nil if unknown.
+//
+// Tokens passed to `AuthenticateToken` are refreshed right away, after which the granted scopes are known.
+func (gp *GotifyPlayer) GrantedScopes() []string {
+	if scps := gp.granted.Load(); scps != nil {
+		return scopeStrings(*scps)
+	}
+	return nil
+}
+
+func scopeStrings(scps []scope) []string {
+	strs := make([]string, len(scps))
+	for i, scp := range scps {
+		strs[i] = string(scp)
+	}
+	return strs
+}
+
+// HasScopes reports whether all scopes are granted, always true if the granted scopes are unknown.
+func (gp *GotifyPlayer) HasScopes(scopes ...scope) bool {
+	granted := gp.granted.Load()
+	if granted == nil {
+		return true
+	}
+	for _, scp := range scopes {
+		if !slices.Contains(*granted, scp) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkScopes returns a `MissingScopeError` if the route requires scopes that are not granted, nothing is checked if the granted scopes are unknown.
+func (gp *GotifyPlayer) checkScopes(method lib.HTTPMethod, action string) error {
+	granted := gp.granted.Load()
+	if granted == nil {
+		return nil
+	}
+	for _, route := range scopeRoutes {
+		if !route.match(method, action) {
+			continue
+		}
+		for _, group := range route.groups {
+			if !slices.ContainsFunc(group, func(scp scope) bool { return slices.Contains(*granted, scp) }) {
+				return &MissingScopeError{Scopes: scopeStrings(group), Method: method, Path: action}
+			}
+		}
+	}
+	return nil
+}
+
+// RequestScopes adds scopes to the requested scopes and authenticates again using authenticate, Ex: `gp.RequestScopes(gp.AuthenticateStdin, gotify.ScopeUserTopRead)`.
+//
+// The requested scopes are restored if authenticate fails.
+func (gp *GotifyPlayer) RequestScopes(authenticate func() error, scopes ...scope) error {
+	prev := slices.Clone(gp.authCfg.Scopes)
+	for _, scp := range scopes {
+		if !slices.Contains(gp.authCfg.Scopes, string(scp)) {
+			gp.authCfg.Scopes = append(gp.authCfg.Scopes, string(scp))
+		}
+	}
+	if err := authenticate(); err != nil {
+		gp.authCfg.Scopes = prev
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package gotify
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/HandyGold75/gotify/lib"
+)
+
+func TestScopeRouteMatch(t *testing.T) {
+	tests := []struct {
+		route  scopeRoute
+		method lib.HTTPMethod
+		action string
+		want   bool
+	}{
+		{route: scopeRoute{methods: []lib.HTTPMethod{lib.POST}, path: "playlists/*/tracks"}, method: lib.POST, action: "playlists/abc/tracks", want: true},
+		{route: scopeRoute{methods: []lib.HTTPMethod{lib.POST}, path: "playlists/*/tracks"}, method: lib.POST, action: "/playlists/abc/tracks/", want: true},
+		{route: scopeRoute{methods: []lib.HTTPMethod{lib.POST}, path: "playlists/*/tracks"}, method: lib.GET, action: "playlists/abc/tracks", want: false},
+		{route: scopeRoute{methods: []lib.HTTPMethod{lib.POST}, path: "playlists/*/tracks"}, method: lib.POST, action: "playlists/abc", want: false},
+		{route: scopeRoute{methods: []lib.HTTPMethod{lib.POST}, path: "playlists/*/tracks"}, method: lib.POST, action: "playlists/abc/images", want: false},
+		{route: scopeRoute{methods: []lib.HTTPMethod{lib.GET}, path: "me/top/*"}, method: lib.GET, action: "me/top/artists", want: true},
+		{route: scopeRoute{methods: []lib.HTTPMethod{lib.GET}, path: "me/top/*"}, method: lib.GET, action: "me/top", want: false},
+		{route: scopeRoute{methods: []lib.HTTPMethod{lib.GET}, path: "player/queue"}, method: lib.GET, action: "player/queue", want: true},
+		{route: scopeRoute{methods: []lib.HTTPMethod{lib.PUT, lib.POST}, path: "player/*"}, method: lib.POST, action: "player/queue", want: true},
+		{route: scopeRoute{methods: []lib.HTTPMethod{lib.PUT, lib.POST}, path: "player/*"}, method: lib.GET, action: "player/queue", want: false},
+	}
+	for _, tt := range tests {
+		if got := tt.route.match(tt.method, tt.action); got != tt.want {
+			t.Errorf("%v %q match(%s, %q) = %v, want %v", tt.route.methods, tt.route.path, tt.method, tt.action, got, tt.want)
+		}
+	}
+}
+
+func TestCheckScopes(t *testing.T) {
+	tests := []struct {
+		name    string
+		granted []scope // Nil if the granted scopes are unknown.
+		method  lib.HTTPMethod
+		action  string
+		want    []string // Scopes of the returned `MissingScopeError`, nil if allowed.
+	}{
+		{name: "unknown scopes", granted: nil, method: lib.GET, action: "me/top/tracks"},
+		{name: "unlisted route", granted: []scope{}, method: lib.GET, action: "albums/abc"},
+		{name: "top granted", granted: []scope{ScopeUserTopRead}, method: lib.GET, action: "me/top/tracks"},
+		{name: "top missing", granted: []scope{}, method: lib.GET, action: "me/top/artists", want: []string{"user-top-read"}},
+		{name: "playlist tracks public", granted: []scope{ScopePlaylistModifyPublic}, method: lib.POST, action: "playlists/abc/tracks"},
+		{name: "playlist tracks private", granted: []scope{ScopePlaylistModifyPrivate}, method: lib.DELETE, action: "playlists/abc/tracks"},
+		{name: "playlist tracks missing", granted: []scope{ScopeUserTopRead}, method: lib.PUT, action: "playlists/abc/tracks", want: []string{"playlist-modify-public", "playlist-modify-private"}},
+		{name: "playlist tracks read", granted: []scope{}, method: lib.GET, action: "playlists/abc/tracks"},
+		{name: "playlist image missing upload", granted: []scope{ScopePlaylistModifyPublic}, method: lib.PUT, action: "playlists/abc/images", want: []string{"ugc-image-upload"}},
+		{name: "queue by currently playing", granted: []scope{ScopeUserReadCurrentlyPlaying}, method: lib.GET, action: "player/queue"},
+		{name: "queue by playback state", granted: []scope{ScopeUserReadPlaybackState}, method: lib.GET, action: "player/queue"},
+		{name: "queue missing", granted: []scope{}, method: lib.GET, action: "player/queue", want: []string{"user-read-currently-playing", "user-read-playback-state"}},
+		{name: "currently playing by playback state", granted: []scope{ScopeUserReadPlaybackState}, method: lib.GET, action: "player/currently-playing"},
+		{name: "add to queue missing", granted: []scope{ScopeUserReadPlaybackState}, method: lib.POST, action: "player/queue", want: []string{"user-modify-playback-state"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gp := NewGotifyPlayer("id", "http://127.0.0.1/callback")
+			if tt.granted != nil {
+				gp.granted.Store(&tt.granted)
+			}
+			err := gp.checkScopes(tt.method, tt.action)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("err = %v, want nil", err)
+				}
+				return
+			}
+			scopeErr := &MissingScopeError{}
+			if !errors.As(err, &scopeErr) || !errors.Is(err, lib.Errors.MissingScope) {
+				t.Fatalf("err = %v, want a MissingScopeError", err)
+			}
+			if !slices.Equal(scopeErr.Scopes, tt.want) || scopeErr.Method != tt.method || scopeErr.Path != tt.action {
+				t.Errorf("err = %+v, want scopes %v", *scopeErr, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package shows
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"strconv"
+	"strings"
+
+	"github.com/HandyGold75/gotify/lib"
+)
+
+type (
+	Shows struct {
+		Send        func(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)
+		Market      string // An ISO 3166-1 alpha-2 country code, https://en.wikipedia.org/wiki/ISO_3166-1_alpha-2
+		Concurrency int    // Maximum batches sent at once when the IDs exceed the Spotify batch limit, values below 2 send batches sequentially.
+	}
+)
+
+func New(send func(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)) Shows {
+	return Shows{Send: send, Market: ""}
+}
+
+// Scopes: `ScopeUserReadPlaybackPosition`
+func (s *Shows) GetShow(id string) (lib.ShowObject, error) {
+	return s.GetShowCtx(context.Background(), id)
+}
+
+// Scopes: `ScopeUserReadPlaybackPosition`
+func (s *Shows) GetShowCtx(ctx context.Context, id string) (lib.ShowObject, error) {
+	res, err := s.Send(ctx, lib.GET, "shows/"+id, [][2]string{{"market", s.Market}}, []byte{})
+	if err != nil {
+		return lib.ShowObject{}, err
+	}
+	data := lib.ShowObject{}
+	err = json.Unmarshal(res, &data)
+	return data, err
+}
+
+func (s *Shows) GetSeveralShows(ids []string) (lib.Shows, error) {
+	return s.GetSeveralShowsCtx(context.Background(), ids)
+}
+
+func (s *Shows) GetSeveralShowsCtx(ctx context.Context, ids []string) (lib.Shows, error) {
+	items, err := lib.Batch(ids, 50, s.Concurrency, func(ids []string) ([]lib.ShowSimpleObject, error) {
+		res, err := s.Send(ctx, lib.GET, "shows", [][2]string{{"market", s.Market}, {"ids", strings.Join(ids, ",")}}, []byte{})
+		if err != nil {
+			return nil, err
+		}
+		data := lib.Shows{}
+		err = json.Unmarshal(res, &data)
+		return data.Shows, err
+	})
+	if err != nil {
+		return lib.Shows{}, err
+	}
+	return lib.Shows{Shows: items}, nil
+}
+
+// Scopes: `ScopeUserReadPlaybackPosition`
+func (s *Shows) GetShowEpisodes(id string, limit, offset int) (lib.Paging[lib.EpisodeSimpleObject], error) {
+	return s.GetShowEpisodesCtx(context.Background(), id, limit, offset)
+}
+
+// Scopes: `ScopeUserReadPlaybackPosition`
+func (s *Shows) GetShowEpisodesCtx(ctx context.Context, id string, limit, offset int) (lib.Paging[lib.EpisodeSimpleObject], error) {
+	res, err := s.Send(ctx, lib.GET, "shows/"+id+"/episodes", [][2]string{{"market", s.Market}, {"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
+	if err != nil {
+		return lib.Paging[lib.EpisodeSimpleObject]{}, err
+	}
+	data := lib.Paging[lib.EpisodeSimpleObject]{}
+	err = json.Unmarshal(res, &data)
+	return data, err
+}
+
+// Scopes: `ScopeUserReadPlaybackPosition`
+func (s *Shows) AllShowEpisodes(id string) iter.Seq2[lib.EpisodeSimpleObject, error] {
+	return s.AllShowEpisodesCtx(context.Background(), id)
+}
+
+// Scopes: `ScopeUserReadPlaybackPosition`
+func (s *Shows) AllShowEpisodesCtx(ctx context.Context, id string) iter.Seq2[lib.EpisodeSimpleObject, error] {
+	return lib.Paginate(func(offset int) ([]lib.EpisodeSimpleObject, lib.ItemsHeaders, error) {
+		data, err := s.GetShowEpisodesCtx(ctx, id, 50, offset)
+		return data.Items, data.ItemsHeaders, err
+	})
+}
+
+// Scopes: `ScopeUserLibraryRead`
+func (s *Shows) GetUsersSavedShows(limit, offset int) (lib.Paging[lib.SavedShow], error) {
+	return s.GetUsersSavedShowsCtx(context.Background(), limit, offset)
+}
+
+// Scopes: `ScopeUserLibraryRead`
+func (s *Shows) GetUsersSavedShowsCtx(ctx context.Context, limit, offset int) (lib.Paging[lib.SavedShow], error) {
+	res, err := s.Send(ctx, lib.GET, "me/shows", [][2]string{{"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
+	if err != nil {
+		return lib.Paging[lib.SavedShow]{}, err
+	}
+	data := lib.Paging[lib.SavedShow]{}
+	err = json.Unmarshal(res, &data)
+	return data, err
+}
+
+// Scopes: `ScopeUserLibraryRead`
+func (s *Shows) AllUsersSavedShows() iter.Seq2[lib.SavedShow, error] {
+	return s.AllUsersSavedShowsCtx(context.Background())
+}
+
+// Scopes: `ScopeUserLibraryRead`
+func (s *Shows) AllUsersSavedShowsCtx(ctx context.Context) iter.Seq2[lib.SavedShow, error] {
+	return lib.Paginate(func(offset int) ([]lib.SavedShow, lib.ItemsHeaders, error) {
+		data, err := s.GetUsersSavedShowsCtx(ctx, 50, offset)
+		return data.Items, data.ItemsHeaders, err
+	})
+}
+
+// Scopes: `ScopeUserLibraryModify`
+func (s *Shows) SaveShowsForCurrentUser(ids []string) error {
+	return s.SaveShowsForCurrentUserCtx(context.Background(), ids)
+}
+
+// Scopes: `ScopeUserLibraryModify`
+func (s *Shows) SaveShowsForCurrentUserCtx(ctx context.Context, ids []string) error {
+	return lib.BatchDo(ids, 50, s.Concurrency, func(ids []string) error {
+		_, err := s.Send(ctx, lib.PUT, "me/shows", [][2]string{{"ids", strings.Join(ids, ",")}}, []byte{})
+		return err
+	})
+}
+
+// Scopes: `ScopeUserLibraryModify`
+func (s *Shows) RemoveUsersSavedShows(ids []string) error {
+	return s.RemoveUsersSavedShowsCtx(context.Background(), ids)
+}
+
+// Scopes: `ScopeUserLibraryModify`
+func (s *Shows) RemoveUsersSavedShowsCtx(ctx context.Context, ids []string) error {
+	return lib.BatchDo(ids, 50, s.Concurrency, func(ids []string) error {
+		_, err := s.Send(ctx, lib.DELETE, "me/shows", [][2]string{{"market", s.Market}, {"ids", strings.Join(ids, ",")}}, []byte{})
+		return err
+	})
+}
+
+// Scopes: `ScopeUserLibraryRead`
+func (s *Shows) CheckUsersSavedShows(ids []string) ([]bool, error) {
+	return s.CheckUsersSavedShowsCtx(context.Background(), ids)
+}
+
+// Scopes: `ScopeUserLibraryRead`
+func (s *Shows) CheckUsersSavedShowsCtx(ctx context.Context, ids []string) ([]bool, error) {
+	return lib.Batch(ids, 50, s.Concurrency, func(ids []string) ([]bool, error) {
+		res, err := s.Send(ctx, lib.GET, "me/shows/contains", [][2]string{{"ids", strings.Join(ids, ",")}}, []byte{})
+		if err != nil {
+			return []bool{}, err
+		}
+		data := []bool{}
+		err = json.Unmarshal(res, &data)
+		return data, err
+	})
+}
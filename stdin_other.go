@@ -0,0 +1,10 @@
+//go:build !unix
+
+package gotify
+
+import "os"
+
+// openStdin returns `os.Stdin`, pending reads can not be interrupted on this platform so the line after an unfinished flow is still consumed.
+func openStdin() (stdin *os.File, release func()) {
+	return os.Stdin, func() {}
+}
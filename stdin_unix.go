@@ -0,0 +1,27 @@
+//go:build unix
+
+package gotify
+
+import (
+	"os"
+	"syscall"
+)
+
+// openStdin returns a duplicate of stdin in non-blocking mode, making pending reads interruptible, release closes it and restores blocking mode for the application.
+//
+// Falls back to `os.Stdin` if stdin can not be duplicated, reads from it can not be interrupted.
+func openStdin() (stdin *os.File, release func()) {
+	fd, err := syscall.Dup(syscall.Stdin)
+	if err != nil {
+		return os.Stdin, func() {}
+	}
+	if err := syscall.SetNonblock(fd, true); err != nil {
+		_ = syscall.Close(fd)
+		return os.Stdin, func() {}
+	}
+	f := os.NewFile(uintptr(fd), os.Stdin.Name())
+	return f, func() {
+		_ = f.Close()
+		_ = syscall.SetNonblock(syscall.Stdin, false)
+	}
+}
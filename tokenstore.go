@@ -0,0 +1,150 @@
+package gotify
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/HandyGold75/gotify/lib"
+	"golang.org/x/oauth2"
+)
+
+type (
+	// TokenStore persists the oauth2 token of a GotifyPlayer, `Save` is called after authenticating and whenever the token is refreshed.
+	TokenStore interface {
+		Load() (*oauth2.Token, error)
+		Save(token *oauth2.Token) error
+	}
+
+	// FileTokenStore stores the token as JSON in a file only readable by the current user.
+	FileTokenStore struct {
+		Path string
+	}
+
+	// MemoryTokenStore stores the token in memory, useful for testing or sharing a token between players.
+	MemoryTokenStore struct {
+		mu    sync.Mutex
+		token *oauth2.Token
+	}
+
+	notifyTokenSource struct {
+		mu     sync.Mutex
+		src    oauth2.TokenSource
+		last   string
+		notify func(token *oauth2.Token)
+	}
+)
+
+func (s *FileTokenStore) Load() (*oauth2.Token, error) {
+	data, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return nil, lib.Errors.NoToken
+	} else if err != nil {
+		return nil, err
+	}
+	token := &oauth2.Token{}
+	if err := json.Unmarshal(data, token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (s *FileTokenStore) Save(token *oauth2.Token) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), "."+filepath.Base(s.Path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0o600); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+func (s *MemoryTokenStore) Load() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token == nil {
+		return nil, lib.Errors.NoToken
+	}
+	token := *s.token
+	return &token, nil
+}
+
+func (s *MemoryTokenStore) Save(token *oauth2.Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := *token
+	s.token = &t
+	return nil
+}
+
+func (s *notifyTokenSource) Token() (*oauth2.Token, error) {
+	token, err := s.src.Token()
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if token.AccessToken != s.last {
+		s.last = token.AccessToken
+		s.notify(token)
+	}
+	return token, nil
+}
+
+// Authenticate using the token loaded from `TokenStore`.
+func (gp *GotifyPlayer) AuthenticateStored() error {
+	return gp.AuthenticateStoredCtx(context.Background())
+}
+
+// Authenticate using the token loaded from `TokenStore`.
+func (gp *GotifyPlayer) AuthenticateStoredCtx(ctx context.Context) error {
+	if gp.TokenStore == nil {
+		return lib.Errors.NoToken
+	}
+	token, err := gp.TokenStore.Load()
+	if err != nil {
+		return err
+	}
+	return gp.AuthenticateTokenCtx(ctx, token)
+}
+
+// useToken backs the client by src, reporting every new token to `OnTokenRefresh` and to `TokenStore` if persist is true.
+func (gp *GotifyPlayer) useToken(token *oauth2.Token, src oauth2.TokenSource, persist bool) {
+	nts := &notifyTokenSource{src: oauth2.ReuseTokenSource(token, src), last: token.AccessToken, notify: func(token *oauth2.Token) { gp.saveToken(token, persist) }}
+	gp.granted.Store(nil)
+	gp.product.Store(nil)
+	gp.productErr.Store(nil)
+	gp.saveToken(token, persist)
+	cl := *gp.baseCl
+	cl.Transport = &oauth2.Transport{Source: nts, Base: gp.baseCl.Transport}
+	gp.cl.Store(&cl)
+}
+
+func (gp *GotifyPlayer) saveToken(token *oauth2.Token, persist bool) {
+	if scps, ok := tokenScopes(token); ok {
+		gp.granted.Store(&scps)
+	}
+	var err error
+	if persist && gp.TokenStore != nil {
+		err = gp.TokenStore.Save(token)
+	}
+	if gp.OnTokenRefresh != nil {
+		gp.OnTokenRefresh(token, err)
+	}
+}
@@ -1,7 +1,9 @@
 package tracks
 
 import (
+	"context"
 	"encoding/json"
+	"iter"
 	"strconv"
 	"strings"
 	"time"
@@ -9,104 +11,151 @@ import (
 	"github.com/HandyGold75/gotify/lib"
 )
 
-type (
-	getTrack lib.TrackObject
-
-	getTracks lib.Tracks
-
-	getUsersSavedTracks struct {
-		lib.ItemsHeaders
-		Items []struct {
-			AddedAt string `json:"added_at"`
-			lib.Track
-		} `json:"items"`
-	}
-)
-
 type Tracks struct {
-	Send   func(method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)
-	Market string // An ISO 3166-1 alpha-2 country code, https://en.wikipedia.org/wiki/ISO_3166-1_alpha-2
+	Send        func(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)
+	Market      string // An ISO 3166-1 alpha-2 country code, https://en.wikipedia.org/wiki/ISO_3166-1_alpha-2
+	Concurrency int    // Maximum batches sent at once when the IDs exceed the Spotify batch limit, values below 2 send batches sequentially.
 }
 
-func New(send func(method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)) Tracks {
+func New(send func(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)) Tracks {
 	return Tracks{
 		Send:   send,
 		Market: "",
 	}
 }
 
-func (s *Tracks) GetTrack(id string) (getTrack, error) {
-	res, err := s.Send(lib.GET, "tracks/"+id, [][2]string{{"market", s.Market}}, []byte{})
+func (s *Tracks) GetTrack(id string) (lib.TrackObject, error) {
+	return s.GetTrackCtx(context.Background(), id)
+}
+
+func (s *Tracks) GetTrackCtx(ctx context.Context, id string) (lib.TrackObject, error) {
+	res, err := s.Send(ctx, lib.GET, "tracks/"+id, [][2]string{{"market", s.Market}}, []byte{})
 	if err != nil {
-		return getTrack{}, err
+		return lib.TrackObject{}, err
 	}
-	data := getTrack{}
+	data := lib.TrackObject{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
 
-func (s *Tracks) GetSeveralTracks(ids []string) (getTracks, error) {
-	res, err := s.Send(lib.GET, "tracks", [][2]string{{"market", s.Market}, {"ids", strings.Join(ids, ",")}}, []byte{})
+func (s *Tracks) GetSeveralTracks(ids []string) (lib.Tracks, error) {
+	return s.GetSeveralTracksCtx(context.Background(), ids)
+}
+
+func (s *Tracks) GetSeveralTracksCtx(ctx context.Context, ids []string) (lib.Tracks, error) {
+	items, err := lib.Batch(ids, 50, s.Concurrency, func(ids []string) ([]lib.TrackObject, error) {
+		res, err := s.Send(ctx, lib.GET, "tracks", [][2]string{{"market", s.Market}, {"ids", strings.Join(ids, ",")}}, []byte{})
+		if err != nil {
+			return nil, err
+		}
+		data := lib.Tracks{}
+		err = json.Unmarshal(res, &data)
+		return data.Tracks, err
+	})
 	if err != nil {
-		return getTracks{}, err
+		return lib.Tracks{}, err
 	}
-	data := getTracks{}
-	err = json.Unmarshal(res, &data)
-	return data, err
+	return lib.Tracks{Tracks: items}, nil
 }
 
 // Scopes: `ScopeUserLibraryRead`
-func (s *Tracks) GetUsersSavedTracks(limit, offset int) (getUsersSavedTracks, error) {
-	res, err := s.Send(lib.GET, "me/tracks", [][2]string{{"market", s.Market}, {"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
+func (s *Tracks) GetUsersSavedTracks(limit, offset int) (lib.Paging[lib.SavedTrack], error) {
+	return s.GetUsersSavedTracksCtx(context.Background(), limit, offset)
+}
+
+// Scopes: `ScopeUserLibraryRead`
+func (s *Tracks) GetUsersSavedTracksCtx(ctx context.Context, limit, offset int) (lib.Paging[lib.SavedTrack], error) {
+	res, err := s.Send(ctx, lib.GET, "me/tracks", [][2]string{{"market", s.Market}, {"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
 	if err != nil {
-		return getUsersSavedTracks{}, err
+		return lib.Paging[lib.SavedTrack]{}, err
 	}
-	data := getUsersSavedTracks{}
+	data := lib.Paging[lib.SavedTrack]{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
 
+// Scopes: `ScopeUserLibraryRead`
+func (s *Tracks) AllUsersSavedTracks() iter.Seq2[lib.SavedTrack, error] {
+	return s.AllUsersSavedTracksCtx(context.Background())
+}
+
+// Scopes: `ScopeUserLibraryRead`
+func (s *Tracks) AllUsersSavedTracksCtx(ctx context.Context) iter.Seq2[lib.SavedTrack, error] {
+	return lib.Paginate(func(offset int) ([]lib.SavedTrack, lib.ItemsHeaders, error) {
+		data, err := s.GetUsersSavedTracksCtx(ctx, 50, offset)
+		return data.Items, data.ItemsHeaders, err
+	})
+}
+
 // Scopes: `ScopeUserLibraryModify`
 func (s *Tracks) SaveTracksForCurrentUser(ids []string) error {
-	body, err := json.Marshal(map[string]any{"ids": ids})
-	if err != nil {
+	return s.SaveTracksForCurrentUserCtx(context.Background(), ids)
+}
+
+// Scopes: `ScopeUserLibraryModify`
+func (s *Tracks) SaveTracksForCurrentUserCtx(ctx context.Context, ids []string) error {
+	return lib.BatchDo(ids, 50, s.Concurrency, func(ids []string) error {
+		body, err := json.Marshal(map[string]any{"ids": ids})
+		if err != nil {
+			return err
+		}
+		_, err = s.Send(ctx, lib.PUT, "me/tracks", [][2]string{}, body)
 		return err
-	}
-	_, err = s.Send(lib.PUT, "me/tracks", [][2]string{}, body)
-	return err
+	})
 }
 
 // Scopes: `ScopeUserLibraryModify`
 func (s *Tracks) SaveTracksForCurrentUserTimestamped(ids []string, timestamp time.Time) error {
-	bodyIds := []map[string]any{}
-	for _, id := range ids {
-		bodyIds = append(bodyIds, map[string]any{"id": id, "added_at": timestamp.Format(time.RFC3339)})
-	}
-	body, err := json.Marshal(map[string]any{"timestamped_ids": bodyIds})
-	if err != nil {
+	return s.SaveTracksForCurrentUserTimestampedCtx(context.Background(), ids, timestamp)
+}
+
+// Scopes: `ScopeUserLibraryModify`
+func (s *Tracks) SaveTracksForCurrentUserTimestampedCtx(ctx context.Context, ids []string, timestamp time.Time) error {
+	return lib.BatchDo(ids, 50, s.Concurrency, func(ids []string) error {
+		bodyIds := []map[string]any{}
+		for _, id := range ids {
+			bodyIds = append(bodyIds, map[string]any{"id": id, "added_at": timestamp.Format(time.RFC3339)})
+		}
+		body, err := json.Marshal(map[string]any{"timestamped_ids": bodyIds})
+		if err != nil {
+			return err
+		}
+		_, err = s.Send(ctx, lib.PUT, "me/tracks", [][2]string{}, body)
 		return err
-	}
-	_, err = s.Send(lib.PUT, "me/tracks", [][2]string{}, body)
-	return err
+	})
 }
 
 // Scopes: `ScopeUserLibraryModify`
 func (s *Tracks) RemoveUsersSavedTracks(ids []string) error {
-	body, err := json.Marshal(map[string]any{"ids": ids})
-	if err != nil {
+	return s.RemoveUsersSavedTracksCtx(context.Background(), ids)
+}
+
+// Scopes: `ScopeUserLibraryModify`
+func (s *Tracks) RemoveUsersSavedTracksCtx(ctx context.Context, ids []string) error {
+	return lib.BatchDo(ids, 50, s.Concurrency, func(ids []string) error {
+		body, err := json.Marshal(map[string]any{"ids": ids})
+		if err != nil {
+			return err
+		}
+		_, err = s.Send(ctx, lib.DELETE, "me/tracks", [][2]string{}, body)
 		return err
-	}
-	_, err = s.Send(lib.DELETE, "me/tracks", [][2]string{}, body)
-	return err
+	})
 }
 
 // Scopes: `ScopeUserLibraryRead`
 func (s *Tracks) CheckUsersSavedTracks(ids []string) ([]bool, error) {
-	res, err := s.Send(lib.GET, "me/tracks/contains", [][2]string{{"ids", strings.Join(ids, ",")}}, []byte{})
-	if err != nil {
-		return []bool{}, err
-	}
-	data := []bool{}
-	err = json.Unmarshal(res, &data)
-	return data, err
+	return s.CheckUsersSavedTracksCtx(context.Background(), ids)
+}
+
+// Scopes: `ScopeUserLibraryRead`
+func (s *Tracks) CheckUsersSavedTracksCtx(ctx context.Context, ids []string) ([]bool, error) {
+	return lib.Batch(ids, 50, s.Concurrency, func(ids []string) ([]bool, error) {
+		res, err := s.Send(ctx, lib.GET, "me/tracks/contains", [][2]string{{"ids", strings.Join(ids, ",")}}, []byte{})
+		if err != nil {
+			return []bool{}, err
+		}
+		data := []bool{}
+		err = json.Unmarshal(res, &data)
+		return data, err
+	})
 }
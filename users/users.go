@@ -1,7 +1,9 @@
 package users
 
 import (
+	"context"
 	"encoding/json"
+	"iter"
 	"strconv"
 	"strings"
 
@@ -10,170 +12,269 @@ import (
 
 type (
 	Users struct {
-		Send     func(method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)
-		DeviceID string
-	}
-
-	getCurrentUsersProfile lib.Profile
-
-	getUsersTopArtists struct {
-		lib.ItemsHeaders
-		Items []lib.ArtistObject `json:"items"`
-	}
-
-	getUsersTopTracks struct {
-		lib.ItemsHeaders
-		Items []lib.TrackObject `json:"items"`
-	}
-
-	getUsersProfile lib.ProfilePublic
-
-	getFollowedArtists struct {
-		Artists struct {
-			lib.ItemsCursorsHeaders
-			Items []lib.ArtistObject `json:"items"`
-		} `json:"artists"`
+		Send        func(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)
+		DeviceID    string
+		Concurrency int // Maximum batches sent at once when the IDs exceed the Spotify batch limit, values below 2 send batches sequentially.
 	}
 )
 
-func New(send func(method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)) Users {
+func New(send func(ctx context.Context, method lib.HTTPMethod, action string, options [][2]string, body []byte) ([]byte, error)) Users {
 	return Users{Send: send, DeviceID: ""}
 }
 
 // Scopes: `ScopeUserReadPrivate`, `ScopeUserReadEmail`
-func (s *Users) GetCurrentUsersProfile() (getCurrentUsersProfile, error) {
-	res, err := s.Send(lib.GET, "me", [][2]string{}, []byte{})
+func (s *Users) GetCurrentUsersProfile() (lib.Profile, error) {
+	return s.GetCurrentUsersProfileCtx(context.Background())
+}
+
+// Scopes: `ScopeUserReadPrivate`, `ScopeUserReadEmail`
+func (s *Users) GetCurrentUsersProfileCtx(ctx context.Context) (lib.Profile, error) {
+	res, err := s.Send(ctx, lib.GET, "me", [][2]string{}, []byte{})
 	if err != nil {
-		return getCurrentUsersProfile{}, err
+		return lib.Profile{}, err
 	}
-	data := getCurrentUsersProfile{}
+	data := lib.Profile{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
 
 // Scopes: `ScopeUserTopRead`
-func (s *Users) GetUsersTopArtists(time lib.TimeRange, limit, offset int) (getUsersTopArtists, error) {
-	res, err := s.Send(lib.GET, "me/top/artists", [][2]string{{"time_range", string(time)}, {"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
+func (s *Users) GetUsersTopArtists(time lib.TimeRange, limit, offset int) (lib.Paging[lib.ArtistObject], error) {
+	return s.GetUsersTopArtistsCtx(context.Background(), time, limit, offset)
+}
+
+// Scopes: `ScopeUserTopRead`
+func (s *Users) GetUsersTopArtistsCtx(ctx context.Context, time lib.TimeRange, limit, offset int) (lib.Paging[lib.ArtistObject], error) {
+	res, err := s.Send(ctx, lib.GET, "me/top/artists", [][2]string{{"time_range", string(time)}, {"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
 	if err != nil {
-		return getUsersTopArtists{}, err
+		return lib.Paging[lib.ArtistObject]{}, err
 	}
-	data := getUsersTopArtists{}
+	data := lib.Paging[lib.ArtistObject]{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
 
 // Scopes: `ScopeUserTopRead`
-func (s *Users) GetUsersTopTracks(time lib.TimeRange, limit, offset int) (getUsersTopTracks, error) {
-	res, err := s.Send(lib.GET, "me/top/tracks", [][2]string{{"time_range", string(time)}, {"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
+func (s *Users) AllUsersTopArtists(time lib.TimeRange) iter.Seq2[lib.ArtistObject, error] {
+	return s.AllUsersTopArtistsCtx(context.Background(), time)
+}
+
+// Scopes: `ScopeUserTopRead`
+func (s *Users) AllUsersTopArtistsCtx(ctx context.Context, time lib.TimeRange) iter.Seq2[lib.ArtistObject, error] {
+	return lib.Paginate(func(offset int) ([]lib.ArtistObject, lib.ItemsHeaders, error) {
+		data, err := s.GetUsersTopArtistsCtx(ctx, time, 50, offset)
+		return data.Items, data.ItemsHeaders, err
+	})
+}
+
+// Scopes: `ScopeUserTopRead`
+func (s *Users) GetUsersTopTracks(time lib.TimeRange, limit, offset int) (lib.Paging[lib.TrackObject], error) {
+	return s.GetUsersTopTracksCtx(context.Background(), time, limit, offset)
+}
+
+// Scopes: `ScopeUserTopRead`
+func (s *Users) GetUsersTopTracksCtx(ctx context.Context, time lib.TimeRange, limit, offset int) (lib.Paging[lib.TrackObject], error) {
+	res, err := s.Send(ctx, lib.GET, "me/top/tracks", [][2]string{{"time_range", string(time)}, {"limit", strconv.Itoa(max(1, min(50, limit)))}, {"offset", strconv.Itoa(max(0, offset))}}, []byte{})
 	if err != nil {
-		return getUsersTopTracks{}, err
+		return lib.Paging[lib.TrackObject]{}, err
 	}
-	data := getUsersTopTracks{}
+	data := lib.Paging[lib.TrackObject]{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
 
-func (s *Users) GetUsersProfile(id string) (getUsersProfile, error) {
-	res, err := s.Send(lib.GET, "users/"+id, [][2]string{}, []byte{})
+// Scopes: `ScopeUserTopRead`
+func (s *Users) AllUsersTopTracks(time lib.TimeRange) iter.Seq2[lib.TrackObject, error] {
+	return s.AllUsersTopTracksCtx(context.Background(), time)
+}
+
+// Scopes: `ScopeUserTopRead`
+func (s *Users) AllUsersTopTracksCtx(ctx context.Context, time lib.TimeRange) iter.Seq2[lib.TrackObject, error] {
+	return lib.Paginate(func(offset int) ([]lib.TrackObject, lib.ItemsHeaders, error) {
+		data, err := s.GetUsersTopTracksCtx(ctx, time, 50, offset)
+		return data.Items, data.ItemsHeaders, err
+	})
+}
+
+func (s *Users) GetUsersProfile(id string) (lib.ProfilePublic, error) {
+	return s.GetUsersProfileCtx(context.Background(), id)
+}
+
+func (s *Users) GetUsersProfileCtx(ctx context.Context, id string) (lib.ProfilePublic, error) {
+	res, err := s.Send(ctx, lib.GET, "users/"+id, [][2]string{}, []byte{})
 	if err != nil {
-		return getUsersProfile{}, err
+		return lib.ProfilePublic{}, err
 	}
-	data := getUsersProfile{}
+	data := lib.ProfilePublic{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
 
 // Scopes: `ScopePlaylistModifyPublic`, `ScopePlaylistModifyPrivate`
 func (s *Users) FollowPlaylist(id string, public bool) error {
+	return s.FollowPlaylistCtx(context.Background(), id, public)
+}
+
+// Scopes: `ScopePlaylistModifyPublic`, `ScopePlaylistModifyPrivate`
+func (s *Users) FollowPlaylistCtx(ctx context.Context, id string, public bool) error {
 	body, err := json.Marshal(map[string]any{"public": public})
 	if err != nil {
 		return err
 	}
-	_, err = s.Send(lib.PUT, "playlists/"+id+"/followers", [][2]string{}, body)
+	_, err = s.Send(ctx, lib.PUT, "playlists/"+id+"/followers", [][2]string{}, body)
 	return err
 }
 
 // Scopes: `ScopePlaylistModifyPublic`, `ScopePlaylistModifyPrivate`
 func (s *Users) UnfollowPlaylist(id string) error {
-	_, err := s.Send(lib.DELETE, "playlists/"+id+"/followers", [][2]string{}, []byte{})
+	return s.UnfollowPlaylistCtx(context.Background(), id)
+}
+
+// Scopes: `ScopePlaylistModifyPublic`, `ScopePlaylistModifyPrivate`
+func (s *Users) UnfollowPlaylistCtx(ctx context.Context, id string) error {
+	_, err := s.Send(ctx, lib.DELETE, "playlists/"+id+"/followers", [][2]string{}, []byte{})
 	return err
 }
 
 // Scopes: `ScopeUserFollowRead`
-func (s *Users) GetFollowedArtists(after string, limit int) (getFollowedArtists, error) {
-	res, err := s.Send(lib.GET, "me/following", [][2]string{{"type", "artists"}, {"after", after}, {"limit", strconv.Itoa(max(1, min(50, limit)))}}, []byte{})
+func (s *Users) GetFollowedArtists(after string, limit int) (lib.FollowedArtists, error) {
+	return s.GetFollowedArtistsCtx(context.Background(), after, limit)
+}
+
+// Scopes: `ScopeUserFollowRead`
+func (s *Users) GetFollowedArtistsCtx(ctx context.Context, after string, limit int) (lib.FollowedArtists, error) {
+	res, err := s.Send(ctx, lib.GET, "me/following", [][2]string{{"type", "artist"}, {"after", after}, {"limit", strconv.Itoa(max(1, min(50, limit)))}}, []byte{})
 	if err != nil {
-		return getFollowedArtists{}, err
+		return lib.FollowedArtists{}, err
 	}
-	data := getFollowedArtists{}
+	data := lib.FollowedArtists{}
 	err = json.Unmarshal(res, &data)
 	return data, err
 }
 
+// Scopes: `ScopeUserFollowRead`
+func (s *Users) AllFollowedArtists() iter.Seq2[lib.ArtistObject, error] {
+	return s.AllFollowedArtistsCtx(context.Background())
+}
+
+// Scopes: `ScopeUserFollowRead`
+func (s *Users) AllFollowedArtistsCtx(ctx context.Context) iter.Seq2[lib.ArtistObject, error] {
+	return lib.PaginateCursor(func(cursor string) ([]lib.ArtistObject, string, error) {
+		data, err := s.GetFollowedArtistsCtx(ctx, cursor, 50)
+		return data.Artists.Items, data.Artists.Cursors.After, err
+	})
+}
+
 // Scopes: `ScopeUserFollowModify`
 func (s *Users) FollowArtists(ids []string) error {
-	body, err := json.Marshal(map[string]any{"ids": ids})
-	if err != nil {
+	return s.FollowArtistsCtx(context.Background(), ids)
+}
+
+// Scopes: `ScopeUserFollowModify`
+func (s *Users) FollowArtistsCtx(ctx context.Context, ids []string) error {
+	return lib.BatchDo(ids, 50, s.Concurrency, func(ids []string) error {
+		body, err := json.Marshal(map[string]any{"ids": ids})
+		if err != nil {
+			return err
+		}
+		_, err = s.Send(ctx, lib.PUT, "me/following", [][2]string{{"type", "artist"}}, body)
 		return err
-	}
-	_, err = s.Send(lib.PUT, "me/following", [][2]string{{"type", "artist"}}, body)
-	return err
+	})
 }
 
 // Scopes: `ScopeUserFollowModify`
 func (s *Users) FollowUsers(ids []string) error {
-	body, err := json.Marshal(map[string]any{"ids": ids})
-	if err != nil {
+	return s.FollowUsersCtx(context.Background(), ids)
+}
+
+// Scopes: `ScopeUserFollowModify`
+func (s *Users) FollowUsersCtx(ctx context.Context, ids []string) error {
+	return lib.BatchDo(ids, 50, s.Concurrency, func(ids []string) error {
+		body, err := json.Marshal(map[string]any{"ids": ids})
+		if err != nil {
+			return err
+		}
+		_, err = s.Send(ctx, lib.PUT, "me/following", [][2]string{{"type", "user"}}, body)
 		return err
-	}
-	_, err = s.Send(lib.PUT, "me/following", [][2]string{{"type", "user"}}, body)
-	return err
+	})
 }
 
 // Scopes: `ScopeUserFollowModify`
 func (s *Users) UnfollowArtists(ids []string) error {
-	body, err := json.Marshal(map[string]any{"ids": ids})
-	if err != nil {
+	return s.UnfollowArtistsCtx(context.Background(), ids)
+}
+
+// Scopes: `ScopeUserFollowModify`
+func (s *Users) UnfollowArtistsCtx(ctx context.Context, ids []string) error {
+	return lib.BatchDo(ids, 50, s.Concurrency, func(ids []string) error {
+		body, err := json.Marshal(map[string]any{"ids": ids})
+		if err != nil {
+			return err
+		}
+		_, err = s.Send(ctx, lib.DELETE, "me/following", [][2]string{{"type", "artist"}}, body)
 		return err
-	}
-	_, err = s.Send(lib.DELETE, "me/following", [][2]string{{"type", "artist"}}, body)
-	return err
+	})
 }
 
 // Scopes: `ScopeUserFollowModify`
 func (s *Users) UnfollowUsers(ids []string) error {
-	body, err := json.Marshal(map[string]any{"ids": ids})
-	if err != nil {
+	return s.UnfollowUsersCtx(context.Background(), ids)
+}
+
+// Scopes: `ScopeUserFollowModify`
+func (s *Users) UnfollowUsersCtx(ctx context.Context, ids []string) error {
+	return lib.BatchDo(ids, 50, s.Concurrency, func(ids []string) error {
+		body, err := json.Marshal(map[string]any{"ids": ids})
+		if err != nil {
+			return err
+		}
+		_, err = s.Send(ctx, lib.DELETE, "me/following", [][2]string{{"type", "user"}}, body)
 		return err
-	}
-	_, err = s.Send(lib.DELETE, "me/following", [][2]string{{"type", "user"}}, body)
-	return err
+	})
 }
 
 // Scopes: `ScopeUserFollowRead`
 func (s *Users) CheckIfUserFollowsArtists(ids []string) ([]bool, error) {
-	res, err := s.Send(lib.GET, "me/following/contains", [][2]string{{"type", "artist"}, {"ids", strings.Join(ids, ",")}}, []byte{})
-	if err != nil {
-		return []bool{}, err
-	}
-	data := []bool{}
-	err = json.Unmarshal(res, &data)
-	return data, err
+	return s.CheckIfUserFollowsArtistsCtx(context.Background(), ids)
+}
+
+// Scopes: `ScopeUserFollowRead`
+func (s *Users) CheckIfUserFollowsArtistsCtx(ctx context.Context, ids []string) ([]bool, error) {
+	return lib.Batch(ids, 50, s.Concurrency, func(ids []string) ([]bool, error) {
+		res, err := s.Send(ctx, lib.GET, "me/following/contains", [][2]string{{"type", "artist"}, {"ids", strings.Join(ids, ",")}}, []byte{})
+		if err != nil {
+			return []bool{}, err
+		}
+		data := []bool{}
+		err = json.Unmarshal(res, &data)
+		return data, err
+	})
 }
 
 // Scopes: `ScopeUserFollowRead`
 func (s *Users) CheckIfUserFollowsUsers(ids []string) ([]bool, error) {
-	res, err := s.Send(lib.GET, "me/following/contains", [][2]string{{"type", "user"}, {"ids", strings.Join(ids, ",")}}, []byte{})
-	if err != nil {
-		return []bool{}, err
-	}
-	data := []bool{}
-	err = json.Unmarshal(res, &data)
-	return data, err
+	return s.CheckIfUserFollowsUsersCtx(context.Background(), ids)
+}
+
+// Scopes: `ScopeUserFollowRead`
+func (s *Users) CheckIfUserFollowsUsersCtx(ctx context.Context, ids []string) ([]bool, error) {
+	return lib.Batch(ids, 50, s.Concurrency, func(ids []string) ([]bool, error) {
+		res, err := s.Send(ctx, lib.GET, "me/following/contains", [][2]string{{"type", "user"}, {"ids", strings.Join(ids, ",")}}, []byte{})
+		if err != nil {
+			return []bool{}, err
+		}
+		data := []bool{}
+		err = json.Unmarshal(res, &data)
+		return data, err
+	})
 }
 
 func (s *Users) CheckIfCurrentUserFollowsPlaylist(id string) (bool, error) {
-	res, err := s.Send(lib.GET, "playlists/"+id+"/followers/contains", [][2]string{}, []byte{})
+	return s.CheckIfCurrentUserFollowsPlaylistCtx(context.Background(), id)
+}
+
+func (s *Users) CheckIfCurrentUserFollowsPlaylistCtx(ctx context.Context, id string) (bool, error) {
+	res, err := s.Send(ctx, lib.GET, "playlists/"+id+"/followers/contains", [][2]string{}, []byte{})
 	if err != nil {
 		return false, err
 	}